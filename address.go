@@ -0,0 +1,209 @@
+package wordgate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrNoUserAddress indicates the user has no address and no fallback address was given
+var ErrNoUserAddress = errors.New("user has no address")
+
+// UserAddressRequest represents a request to create or update a user's address
+type UserAddressRequest struct {
+	// Name is the recipient name
+	Name string `json:"name" binding:"required,max=50"`
+	// Phone is the recipient phone number
+	Phone string `json:"phone" binding:"required,max=20"`
+	// Province is the province/state
+	Province string `json:"province" binding:"required"`
+	// City is the city
+	City string `json:"city" binding:"required"`
+	// District is the district/county (optional)
+	District string `json:"district,omitempty"`
+	// Street is the street address
+	Street string `json:"street" binding:"required,max=200"`
+	// PostalCode is the postal/zip code (optional)
+	PostalCode string `json:"postal_code,omitempty"`
+	// Label is the address label (home, office, etc.) (optional)
+	Label string `json:"label,omitempty"`
+	// IsDefault makes this the user's default address
+	IsDefault bool `json:"is_default"`
+}
+
+// Validate checks that the required address fields are set
+//
+// Returns an error listing the missing fields, or nil if the request is complete
+func (r *UserAddressRequest) Validate() error {
+	var missing []string
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"name", r.Name},
+		{"phone", r.Phone},
+		{"province", r.Province},
+		{"city", r.City},
+		{"street", r.Street},
+	} {
+		if strings.TrimSpace(field.value) == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("invalid address: missing %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ListUserAddresses retrieves all addresses of a user
+//
+// userUID: The user UID to list addresses for
+// Returns the user's addresses and any error
+func (c *Client) ListUserAddresses(userUID string) ([]UserAddress, error) {
+	var result []UserAddress
+	path := fmt.Sprintf("/app/users/%s/addresses", url.PathEscape(userUID))
+	err := c.requestJSON("GET", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user addresses: %w", err)
+	}
+	return result, nil
+}
+
+// GetUserAddress retrieves a user's address by ID
+//
+// userUID: The user UID the address belongs to
+// addressID: The address ID to retrieve
+// Returns the address details and any error
+func (c *Client) GetUserAddress(userUID string, addressID uint64) (*UserAddress, error) {
+	var result UserAddress
+	path := fmt.Sprintf("/app/users/%s/addresses/%d", url.PathEscape(userUID), addressID)
+	err := c.requestJSON("GET", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user address: %w", err)
+	}
+	return &result, nil
+}
+
+// CreateUserAddress creates a new address for a user
+//
+// userUID: The user UID to create the address for
+// request: The address details; required fields are validated before the request is sent
+// Returns the created address and any error
+func (c *Client) CreateUserAddress(userUID string, request *UserAddressRequest) (*UserAddress, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to create user address: %w", err)
+	}
+
+	var result UserAddress
+	path := fmt.Sprintf("/app/users/%s/addresses", url.PathEscape(userUID))
+	err := c.requestJSON("POST", path, request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user address: %w", err)
+	}
+	return &result, nil
+}
+
+// UpdateUserAddress updates an existing address of a user
+//
+// userUID: The user UID the address belongs to
+// addressID: The address ID to update
+// request: The new address details; required fields are validated before the request is sent
+// Returns the updated address and any error
+func (c *Client) UpdateUserAddress(userUID string, addressID uint64, request *UserAddressRequest) (*UserAddress, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to update user address: %w", err)
+	}
+
+	var result UserAddress
+	path := fmt.Sprintf("/app/users/%s/addresses/%d", url.PathEscape(userUID), addressID)
+	err := c.requestJSON("PUT", path, request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user address: %w", err)
+	}
+	return &result, nil
+}
+
+// DeleteUserAddress deletes an address of a user
+//
+// userUID: The user UID the address belongs to
+// addressID: The address ID to delete
+// Returns any error encountered during deletion
+func (c *Client) DeleteUserAddress(userUID string, addressID uint64) error {
+	var result map[string]interface{}
+	path := fmt.Sprintf("/app/users/%s/addresses/%d", url.PathEscape(userUID), addressID)
+	err := c.requestJSON("DELETE", path, nil, &result)
+	if err != nil {
+		return fmt.Errorf("failed to delete user address: %w", err)
+	}
+	return nil
+}
+
+// SetDefaultUserAddress makes an address the user's default address
+//
+// userUID: The user UID the address belongs to
+// addressID: The address ID to make default
+// Returns the updated address and any error
+func (c *Client) SetDefaultUserAddress(userUID string, addressID uint64) (*UserAddress, error) {
+	var result UserAddress
+	path := fmt.Sprintf("/app/users/%s/addresses/%d/default", url.PathEscape(userUID), addressID)
+	err := c.requestJSON("POST", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set default user address: %w", err)
+	}
+	return &result, nil
+}
+
+// EnsureDefaultUserAddress returns the user's default address, creating one if needed
+//
+// If the user has addresses but none is marked default, the first one is made default.
+// If the user has no address, fallback is created as the default address.
+//
+// userUID: The user UID to resolve the default address for
+// fallback: The address to create when the user has none (optional)
+// Returns the default address, or ErrNoUserAddress if the user has none and fallback is nil
+func (c *Client) EnsureDefaultUserAddress(userUID string, fallback *UserAddressRequest) (*UserAddress, error) {
+	addresses, err := c.ListUserAddresses(userUID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range addresses {
+		if addresses[i].IsDefault {
+			return &addresses[i], nil
+		}
+	}
+	if len(addresses) > 0 {
+		return c.SetDefaultUserAddress(userUID, addresses[0].ID)
+	}
+
+	if fallback == nil {
+		return nil, ErrNoUserAddress
+	}
+	request := *fallback
+	request.IsDefault = true
+	return c.CreateUserAddress(userUID, &request)
+}
+
+// AssignDefaultAddress sets the AddressID of a product order request to the user's default address
+//
+// Requests that already have an AddressID are left unchanged.
+//
+// request: The product order request; UserUID must be set
+// fallback: The address to create when the user has none (optional)
+// Returns any error resolving the address
+func (c *Client) AssignDefaultAddress(request *CreateAppProductOrderRequest, fallback *UserAddressRequest) error {
+	if request.AddressID != 0 {
+		return nil
+	}
+	if request.UserUID == "" {
+		return fmt.Errorf("failed to assign default address: user UID is required")
+	}
+
+	address, err := c.EnsureDefaultUserAddress(request.UserUID, fallback)
+	if err != nil {
+		return fmt.Errorf("failed to assign default address: %w", err)
+	}
+	request.AddressID = address.ID
+	return nil
+}
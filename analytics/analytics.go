@@ -0,0 +1,86 @@
+/*
+Package analytics computes revenue and subscription metrics from WordGate orders and memberships.
+
+Revenue aggregates paid orders into time buckets by currency and, from order details, by
+product. Memberships normalize membership history into monthly recurring revenue (MRR), ARPU,
+new/churned/reactivated member counts and cohort retention tables.
+
+Usage example:
+
+	// Daily revenue by currency over January
+	revenue := analytics.NewRevenue(analytics.Day)
+	err := revenue.AddOrders(client.IterateAppOrders(&wordgate.ListOrdersQuery{
+		Status:  "paid",
+		StartAt: "2024-01-01",
+		EndAt:   "2024-01-31",
+	}))
+	for _, row := range revenue.ByCurrency() {
+		fmt.Printf("%s %s %d\n", row.Bucket.Format("2006-01-02"), row.Currency, row.Amount)
+	}
+
+	// MRR and member movements from membership history
+	var members analytics.Memberships
+	for _, user := range users {
+		detail, _ := client.GetUser(user.UID)
+		for _, item := range detail.Membership.History {
+			members.Add(analytics.Membership{UserID: user.UID, Item: item, Amount: priceOf(item), Currency: "CNY"})
+		}
+	}
+	mrr := members.MRR(time.Now())
+	movements := members.Movements(analytics.Month, start, end)
+	cohorts := members.Cohorts(analytics.Month, start, end)
+*/
+package analytics
+
+import (
+	"time"
+)
+
+// Interval is the width of a time bucket
+type Interval string
+
+const (
+	// Day buckets start at midnight
+	Day Interval = "day"
+	// Week buckets start on Monday at midnight
+	Week Interval = "week"
+	// Month buckets start on the first day of the month at midnight
+	Month Interval = "month"
+)
+
+// Start returns the beginning of the bucket containing t, in t's location
+func (i Interval) Start(t time.Time) time.Time {
+	year, month, day := t.Date()
+	switch i {
+	case Week:
+		// Go weeks start on Sunday; shift so that Monday is day 0
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	case Month:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// Next returns the beginning of the bucket following the one containing t
+func (i Interval) Next(t time.Time) time.Time {
+	start := i.Start(t)
+	switch i {
+	case Week:
+		return start.AddDate(0, 0, 7)
+	case Month:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// Buckets returns the starts of all buckets overlapping [start, end)
+func (i Interval) Buckets(start, end time.Time) []time.Time {
+	var buckets []time.Time
+	for t := i.Start(start); t.Before(end); t = i.Next(t) {
+		buckets = append(buckets, t)
+	}
+	return buckets
+}
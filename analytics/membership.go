@@ -0,0 +1,267 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// periodTypes are all period types, used to infer the period from membership dates
+var periodTypes = []wordgate.MembershipPeriodType{
+	wordgate.PeriodTypeMonth,
+	wordgate.PeriodTypeQuarter,
+	wordgate.PeriodTypeHalfYear,
+	wordgate.PeriodTypeYear,
+	wordgate.PeriodTypeTwoYear,
+	wordgate.PeriodTypeThreeYear,
+	wordgate.PeriodTypeFiveYear,
+}
+
+// Membership is one paid membership period of a user
+type Membership struct {
+	// UserID identifies the user (e.g., the user UID)
+	UserID string
+	// Item is the membership history record
+	Item wordgate.UserMembershipDetailItem
+	// PeriodType is the purchased period (inferred from the item dates when empty)
+	PeriodType wordgate.MembershipPeriodType
+	// Amount is the price paid for the whole period in cents
+	Amount int64
+	// Currency is the currency code of Amount
+	Currency string
+}
+
+// Months returns the number of months the membership was paid for, or 0 if unknown
+func (m *Membership) Months() int {
+	periodType := m.PeriodType
+	if periodType == "" {
+		periodType = InferPeriodType(m.Item.StartDate, m.Item.EndDate)
+	}
+	return wordgate.GetMonthsByPeriodType(periodType)
+}
+
+// MonthlyAmount returns the amount normalized to one month, or 0 if the period is unknown
+func (m *Membership) MonthlyAmount() int64 {
+	months := m.Months()
+	if months == 0 {
+		return 0
+	}
+	return m.Amount / int64(months)
+}
+
+// ActiveAt reports whether the membership covers t; canceled memberships are never active
+func (m *Membership) ActiveAt(t time.Time) bool {
+	return !m.Item.IsCanceled && !t.Before(m.Item.StartDate) && t.Before(m.Item.EndDate)
+}
+
+// InferPeriodType returns the period type whose length matches the dates, or "" if none does
+func InferPeriodType(start, end time.Time) wordgate.MembershipPeriodType {
+	months := int(math.Round(end.Sub(start).Hours() / 24 / 30.4375))
+	for _, periodType := range periodTypes {
+		if wordgate.GetMonthsByPeriodType(periodType) == months {
+			return periodType
+		}
+	}
+	return ""
+}
+
+// MRRReport is the monthly recurring revenue at a point in time
+type MRRReport struct {
+	// At is the time the report was computed for
+	At time.Time
+	// MRR is the monthly recurring revenue in cents by currency
+	MRR map[string]int64
+	// ActiveMembers is the number of users with an active membership
+	ActiveMembers int
+	// ARPU is the average monthly revenue per active member in cents by currency
+	ARPU map[string]int64
+}
+
+// MovementRow counts member movements within a bucket
+type MovementRow struct {
+	// Bucket is the beginning of the time bucket
+	Bucket time.Time
+	// Active is the number of members active at the beginning of the bucket
+	Active int
+	// New is the number of users whose first membership started in the bucket
+	New int
+	// Churned is the number of users whose membership lapsed in the bucket without renewal
+	Churned int
+	// Reactivated is the number of returning users whose membership restarted after a lapse
+	Reactivated int
+}
+
+// Cohort is the retention of users whose first membership started in the same bucket
+type Cohort struct {
+	// Start is the beginning of the cohort bucket
+	Start time.Time
+	// Size is the number of users in the cohort
+	Size int
+	// Retained is the number of cohort users active during each bucket since Start;
+	// Retained[0] equals Size
+	Retained []int
+}
+
+// Rate returns the share of the cohort retained after the given number of buckets
+func (c *Cohort) Rate(offset int) float64 {
+	if c.Size == 0 || offset < 0 || offset >= len(c.Retained) {
+		return 0
+	}
+	return float64(c.Retained[offset]) / float64(c.Size)
+}
+
+// span is a continuous stretch of membership coverage
+type span struct {
+	start time.Time
+	end   time.Time
+}
+
+// Memberships computes subscription metrics over the membership history of many users
+type Memberships struct {
+	// Grace is how long a user may be without a membership before a renewal counts as
+	// reactivation instead of continuation
+	Grace time.Duration
+	// Location is the time zone buckets are aligned to (defaults to UTC)
+	Location *time.Location
+
+	items []Membership
+}
+
+// Add adds membership periods; canceled periods are ignored
+func (ms *Memberships) Add(memberships ...Membership) {
+	for _, m := range memberships {
+		if !m.Item.IsCanceled {
+			ms.items = append(ms.items, m)
+		}
+	}
+}
+
+// MRR computes the monthly recurring revenue of the memberships active at a time
+func (ms *Memberships) MRR(at time.Time) MRRReport {
+	report := MRRReport{
+		At:   at,
+		MRR:  make(map[string]int64),
+		ARPU: make(map[string]int64),
+	}
+	users := make(map[string]bool)
+	usersByCurrency := make(map[string]map[string]bool)
+	for i := range ms.items {
+		m := &ms.items[i]
+		if !m.ActiveAt(at) {
+			continue
+		}
+		users[m.UserID] = true
+		report.MRR[m.Currency] += m.MonthlyAmount()
+		if usersByCurrency[m.Currency] == nil {
+			usersByCurrency[m.Currency] = make(map[string]bool)
+		}
+		usersByCurrency[m.Currency][m.UserID] = true
+	}
+	report.ActiveMembers = len(users)
+	for currency, mrr := range report.MRR {
+		report.ARPU[currency] = mrr / int64(len(usersByCurrency[currency]))
+	}
+	return report
+}
+
+// Movements counts new, churned and reactivated members per bucket within [start, end)
+//
+// Memberships lapse at their end date; pass an end no later than now so that scheduled
+// expiries are not counted as churn.
+func (ms *Memberships) Movements(interval Interval, start, end time.Time) []MovementRow {
+	buckets := interval.Buckets(ms.in(start), ms.in(end))
+	rows := make([]MovementRow, len(buckets))
+	index := make(map[time.Time]int, len(buckets))
+	for i, bucket := range buckets {
+		rows[i].Bucket = bucket
+		index[bucket] = i
+	}
+
+	for _, spans := range ms.spans() {
+		for i, s := range spans {
+			if row, ok := index[interval.Start(ms.in(s.start))]; ok {
+				if i == 0 {
+					rows[row].New++
+				} else {
+					rows[row].Reactivated++
+				}
+			}
+			if row, ok := index[interval.Start(ms.in(s.end))]; ok && s.end.Before(end) {
+				rows[row].Churned++
+			}
+			for j := range rows {
+				if !rows[j].Bucket.Before(s.start) && rows[j].Bucket.Before(s.end) {
+					rows[j].Active++
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// Cohorts builds retention tables for users whose first membership started within [start, end)
+//
+// Retention is measured for every bucket from the cohort start up to end.
+func (ms *Memberships) Cohorts(interval Interval, start, end time.Time) []Cohort {
+	buckets := interval.Buckets(ms.in(start), ms.in(end))
+	cohorts := make([]Cohort, len(buckets))
+	index := make(map[time.Time]int, len(buckets))
+	for i, bucket := range buckets {
+		cohorts[i] = Cohort{Start: bucket, Retained: make([]int, len(buckets)-i)}
+		index[bucket] = i
+	}
+
+	for _, spans := range ms.spans() {
+		c, ok := index[interval.Start(ms.in(spans[0].start))]
+		if !ok {
+			continue
+		}
+		cohorts[c].Size++
+		for offset := range cohorts[c].Retained {
+			bucketStart := buckets[c+offset]
+			bucketEnd := interval.Next(bucketStart)
+			for _, s := range spans {
+				if s.start.Before(bucketEnd) && s.end.After(bucketStart) {
+					cohorts[c].Retained[offset]++
+					break
+				}
+			}
+		}
+	}
+	return cohorts
+}
+
+// spans merges the memberships of each user into continuous coverage, allowing Grace between periods
+func (ms *Memberships) spans() map[string][]span {
+	byUser := make(map[string][]span)
+	for _, m := range ms.items {
+		byUser[m.UserID] = append(byUser[m.UserID], span{start: m.Item.StartDate, end: m.Item.EndDate})
+	}
+
+	for user, spans := range byUser {
+		sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+		merged := spans[:1]
+		for _, s := range spans[1:] {
+			last := &merged[len(merged)-1]
+			if !s.start.After(last.end.Add(ms.Grace)) {
+				if s.end.After(last.end) {
+					last.end = s.end
+				}
+				continue
+			}
+			merged = append(merged, s)
+		}
+		byUser[user] = merged
+	}
+	return byUser
+}
+
+// in converts a time to the configured location
+func (ms *Memberships) in(t time.Time) time.Time {
+	if ms.Location == nil {
+		return t.In(time.UTC)
+	}
+	return t.In(ms.Location)
+}
@@ -0,0 +1,179 @@
+package analytics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func membership(user string, start, end time.Time, amount int64, currency string) Membership {
+	return Membership{
+		UserID:   user,
+		Item:     wordgate.UserMembershipDetailItem{StartDate: start, EndDate: end},
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+func TestInferPeriodType(t *testing.T) {
+	start := date(2024, 1, 31)
+	tests := []struct {
+		end  time.Time
+		want wordgate.MembershipPeriodType
+	}{
+		{date(2024, 2, 29), wordgate.PeriodTypeMonth},
+		{date(2024, 4, 30), wordgate.PeriodTypeQuarter},
+		{date(2024, 7, 31), wordgate.PeriodTypeHalfYear},
+		{date(2025, 1, 31), wordgate.PeriodTypeYear},
+		{date(2026, 1, 31), wordgate.PeriodTypeTwoYear},
+		{date(2027, 1, 31), wordgate.PeriodTypeThreeYear},
+		{date(2029, 1, 31), wordgate.PeriodTypeFiveYear},
+		{date(2024, 3, 16), wordgate.PeriodTypeMonth}, // lengths round to whole months
+		{date(2024, 3, 31), ""},
+		{start, ""},
+	}
+	for _, tt := range tests {
+		if got := InferPeriodType(start, tt.end); got != tt.want {
+			t.Errorf("InferPeriodType(%s, %s) = %q, want %q", start.Format(time.DateOnly), tt.end.Format(time.DateOnly), got, tt.want)
+		}
+	}
+}
+
+func TestMembershipsMRR(t *testing.T) {
+	var ms Memberships
+	canceled := membership("D", date(2024, 1, 1), date(2024, 2, 1), 99900, "CNY")
+	canceled.Item.IsCanceled = true
+	explicit := membership("E", date(2024, 3, 1), date(2024, 4, 15), 6000, "USD")
+	explicit.PeriodType = wordgate.PeriodTypeHalfYear
+	ms.Add(
+		membership("A", date(2024, 1, 1), date(2025, 1, 1), 12000, "CNY"),
+		membership("B", date(2024, 3, 1), date(2024, 4, 1), 3000, "CNY"),
+		membership("C", date(2024, 2, 15), date(2024, 5, 15), 9000, "USD"),
+		canceled,
+		explicit,
+	)
+
+	tests := []struct {
+		at     time.Time
+		mrr    map[string]int64
+		arpu   map[string]int64
+		active int
+	}{
+		{
+			at:     date(2024, 3, 10),
+			mrr:    map[string]int64{"CNY": 4000, "USD": 4000},
+			arpu:   map[string]int64{"CNY": 2000, "USD": 2000},
+			active: 4,
+		},
+		// Memberships end exclusively
+		{
+			at:     date(2024, 4, 1),
+			mrr:    map[string]int64{"CNY": 1000, "USD": 4000},
+			arpu:   map[string]int64{"CNY": 1000, "USD": 2000},
+			active: 3,
+		},
+		{
+			at:     date(2025, 1, 1),
+			mrr:    map[string]int64{},
+			arpu:   map[string]int64{},
+			active: 0,
+		},
+	}
+	for _, tt := range tests {
+		report := ms.MRR(tt.at)
+		if !reflect.DeepEqual(report.MRR, tt.mrr) || !reflect.DeepEqual(report.ARPU, tt.arpu) || report.ActiveMembers != tt.active {
+			t.Errorf("MRR(%s) = %+v, want MRR %v ARPU %v active %d", tt.at.Format(time.DateOnly), report, tt.mrr, tt.arpu, tt.active)
+		}
+	}
+}
+
+// movementHistory covers a renewal inside a week of grace (cont), a return after the grace
+// (react), a member from before the range (old) and memberships ending after the range (late, cut)
+func movementHistory(grace time.Duration) *Memberships {
+	ms := &Memberships{Grace: grace}
+	ms.Add(
+		membership("old", date(2023, 11, 1), date(2024, 2, 1), 3000, "CNY"),
+		membership("cont", date(2024, 1, 1), date(2024, 2, 1), 1000, "CNY"),
+		membership("cont", date(2024, 2, 5), date(2024, 3, 5), 1000, "CNY"),
+		membership("react", date(2024, 1, 10), date(2024, 2, 10), 1000, "CNY"),
+		membership("react", date(2024, 4, 1), date(2024, 5, 1), 1000, "CNY"),
+		membership("late", date(2024, 3, 1), date(2024, 7, 1), 4000, "CNY"),
+		membership("cut", date(2024, 4, 1), date(2024, 5, 25), 2000, "CNY"),
+	)
+	return ms
+}
+
+func TestMembershipsMovements(t *testing.T) {
+	start, end := date(2024, 1, 1), date(2024, 5, 20)
+	row := func(month time.Month, active, new, churned, reactivated int) MovementRow {
+		return MovementRow{Bucket: date(2024, month, 1), Active: active, New: new, Churned: churned, Reactivated: reactivated}
+	}
+
+	tests := []struct {
+		name  string
+		grace time.Duration
+		want  []MovementRow
+	}{
+		{
+			// cont renews within the grace and churns only in March; cut ends after end
+			// and is not counted as churn
+			name:  "week of grace",
+			grace: 7 * 24 * time.Hour,
+			want: []MovementRow{
+				row(time.January, 2, 2, 0, 0),
+				row(time.February, 2, 0, 2, 0),
+				row(time.March, 2, 1, 1, 0),
+				row(time.April, 3, 1, 0, 1),
+				row(time.May, 2, 0, 1, 0),
+			},
+		},
+		{
+			// Without grace the four-day gap of cont is a churn and a reactivation
+			name:  "no grace",
+			grace: 0,
+			want: []MovementRow{
+				row(time.January, 2, 2, 0, 0),
+				row(time.February, 1, 0, 3, 1),
+				row(time.March, 2, 1, 1, 0),
+				row(time.April, 3, 1, 0, 1),
+				row(time.May, 2, 0, 1, 0),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := movementHistory(tt.grace).Movements(Month, start, end)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Movements() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMembershipsCohorts(t *testing.T) {
+	cohorts := movementHistory(7*24*time.Hour).Cohorts(Month, date(2024, 1, 1), date(2024, 5, 20))
+
+	want := []Cohort{
+		// cont and react; react is back in April after lapsing in March
+		{Start: date(2024, 1, 1), Size: 2, Retained: []int{2, 2, 1, 1, 0}},
+		{Start: date(2024, 2, 1), Size: 0, Retained: []int{0, 0, 0, 0}},
+		{Start: date(2024, 3, 1), Size: 1, Retained: []int{1, 1, 1}},
+		{Start: date(2024, 4, 1), Size: 1, Retained: []int{1, 1}},
+		{Start: date(2024, 5, 1), Size: 0, Retained: []int{0}},
+	}
+	if !reflect.DeepEqual(cohorts, want) {
+		t.Fatalf("Cohorts() =\n%+v\nwant\n%+v", cohorts, want)
+	}
+	if rate := cohorts[0].Rate(2); rate != 0.5 {
+		t.Errorf("Rate(2) = %v, want 0.5", rate)
+	}
+	if rate := cohorts[1].Rate(0); rate != 0 {
+		t.Errorf("empty cohort Rate(0) = %v, want 0", rate)
+	}
+}
@@ -0,0 +1,181 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// RevenueRow is the revenue of one bucket, currency and (for product rows) product
+type RevenueRow struct {
+	// Bucket is the beginning of the time bucket
+	Bucket time.Time
+	// Currency is the currency code
+	Currency string
+	// Product is the product name (empty for currency totals)
+	Product string
+	// Amount is the revenue in cents
+	Amount int64
+	// Orders is the number of paid orders contributing to the row
+	Orders int
+}
+
+// revenueKey identifies a revenue row
+type revenueKey struct {
+	bucket   time.Time
+	currency string
+	product  string
+}
+
+// Revenue aggregates paid orders into time buckets by currency and product
+//
+// Orders are bucketed by payment time. Feed each order once, either as a list item
+// (currency totals only) or as order details (currency and product totals).
+// The zero value aggregates into daily UTC buckets.
+type Revenue struct {
+	// Interval is the bucket width
+	Interval Interval
+	// Location is the time zone buckets are aligned to (defaults to UTC)
+	Location *time.Location
+
+	currencies map[revenueKey]*RevenueRow
+	products   map[revenueKey]*RevenueRow
+}
+
+// NewRevenue creates an empty revenue aggregation
+//
+// interval: The bucket width
+func NewRevenue(interval Interval) *Revenue {
+	return &Revenue{
+		Interval:   interval,
+		Location:   time.UTC,
+		currencies: make(map[revenueKey]*RevenueRow),
+		products:   make(map[revenueKey]*RevenueRow),
+	}
+}
+
+// AddOrder adds a paid order to the currency totals; unpaid orders are ignored
+func (r *Revenue) AddOrder(order *wordgate.OrderListItem) {
+	if !order.IsPaid || order.PaidAt == nil {
+		return
+	}
+	r.init()
+	bucket := r.bucket(*order.PaidAt)
+	r.add(r.currencies, bucket, order.Currency, "", order.Amount)
+}
+
+// AddOrders adds all orders of an iterator to the currency totals
+//
+// Returns the iterator error, if any
+func (r *Revenue) AddOrders(it *wordgate.OrderIterator) error {
+	for it.Next() {
+		r.AddOrder(it.Order())
+	}
+	return it.Err()
+}
+
+// AddOrderDetail adds a paid order to the currency and product totals; unpaid orders are ignored
+//
+// The paid amount (after discount) is split across products in proportion to item subtotals.
+//
+// Returns an error if the payment time cannot be parsed
+func (r *Revenue) AddOrderDetail(order *wordgate.OrderDetailResponse) error {
+	if !order.IsPaid || order.PaidAt == nil {
+		return nil
+	}
+	paidAt, err := time.Parse(time.RFC3339, *order.PaidAt)
+	if err != nil {
+		return fmt.Errorf("failed to parse paid time of order %s: %w", order.OrderNo, err)
+	}
+	r.init()
+	bucket := r.bucket(paidAt)
+	r.add(r.currencies, bucket, order.Currency, "", order.Amount)
+
+	var subtotal int64
+	for _, item := range order.Items {
+		subtotal += item.Subtotal
+	}
+
+	// Sum the shares per product first so that an order counts once per product
+	var names []string
+	shares := make(map[string]int64)
+	allocated := int64(0)
+	for i, item := range order.Items {
+		share := order.Amount - allocated
+		if i < len(order.Items)-1 && subtotal > 0 {
+			share = order.Amount * item.Subtotal / subtotal
+		}
+		allocated += share
+		if _, ok := shares[item.ItemName]; !ok {
+			names = append(names, item.ItemName)
+		}
+		shares[item.ItemName] += share
+	}
+	for _, name := range names {
+		r.add(r.products, bucket, order.Currency, name, shares[name])
+	}
+	return nil
+}
+
+// ByCurrency returns revenue totals per bucket and currency, ordered by bucket then currency
+func (r *Revenue) ByCurrency() []RevenueRow {
+	return sortedRows(r.currencies)
+}
+
+// ByProduct returns revenue per bucket, currency and product from order details,
+// ordered by bucket, currency then product
+func (r *Revenue) ByProduct() []RevenueRow {
+	return sortedRows(r.products)
+}
+
+// init allocates the row maps of a zero-value Revenue
+func (r *Revenue) init() {
+	if r.currencies == nil {
+		r.currencies = make(map[revenueKey]*RevenueRow)
+	}
+	if r.products == nil {
+		r.products = make(map[revenueKey]*RevenueRow)
+	}
+}
+
+// bucket returns the bucket of a time in the configured location
+func (r *Revenue) bucket(t time.Time) time.Time {
+	location := r.Location
+	if location == nil {
+		location = time.UTC
+	}
+	return r.Interval.Start(t.In(location))
+}
+
+// add accumulates the amount of one order into a row
+func (r *Revenue) add(rows map[revenueKey]*RevenueRow, bucket time.Time, currency, product string, amount int64) {
+	key := revenueKey{bucket: bucket, currency: currency, product: product}
+	row, ok := rows[key]
+	if !ok {
+		row = &RevenueRow{Bucket: bucket, Currency: currency, Product: product}
+		rows[key] = row
+	}
+	row.Amount += amount
+	row.Orders++
+}
+
+// sortedRows returns the rows ordered by bucket, currency and product
+func sortedRows(rows map[revenueKey]*RevenueRow) []RevenueRow {
+	result := make([]RevenueRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if !a.Bucket.Equal(b.Bucket) {
+			return a.Bucket.Before(b.Bucket)
+		}
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		return a.Product < b.Product
+	})
+	return result
+}
@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+func TestRevenueZeroValueCountsOrdersOnce(t *testing.T) {
+	var revenue Revenue
+	paidAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	revenue.AddOrder(&wordgate.OrderListItem{OrderNo: "WG1", IsPaid: true, PaidAt: &paidAt, Amount: 1000, Currency: "CNY"})
+	revenue.AddOrder(&wordgate.OrderListItem{OrderNo: "WG2", IsPaid: false, Amount: 500, Currency: "CNY"})
+
+	paid := paidAt.Format(time.RFC3339)
+	err := revenue.AddOrderDetail(&wordgate.OrderDetailResponse{
+		OrderNo:  "WG3",
+		IsPaid:   true,
+		PaidAt:   &paid,
+		Amount:   900,
+		Currency: "CNY",
+		Items: []wordgate.OrderItemInfo{
+			{ItemName: "Book", Subtotal: 500},
+			{ItemName: "Pen", Subtotal: 250},
+			{ItemName: "Book", Subtotal: 250},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	currencies := revenue.ByCurrency()
+	if len(currencies) != 1 || currencies[0].Amount != 1900 || currencies[0].Orders != 2 {
+		t.Fatalf("ByCurrency() = %+v, want one CNY row of 1900 from 2 orders", currencies)
+	}
+	if !currencies[0].Bucket.Equal(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("bucket = %v, want 2024-01-15 UTC", currencies[0].Bucket)
+	}
+
+	products := revenue.ByProduct()
+	if len(products) != 2 {
+		t.Fatalf("ByProduct() = %+v, want 2 rows", products)
+	}
+	for _, row := range products {
+		if row.Orders != 1 {
+			t.Errorf("product %s counted %d orders, want 1", row.Product, row.Orders)
+		}
+	}
+	if products[0].Product != "Book" || products[0].Amount != 675 || products[1].Amount != 225 {
+		t.Errorf("ByProduct() = %+v, want Book 675 and Pen 225", products)
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Package catalog keeps WordGate products and membership tiers in sync with a declarative manifest.
+
+A manifest lists the products and tiers an application should have, in YAML or JSON. A Syncer
+diffs it against ListProducts and ListMembershipTiers, including deleted resources, and produces
+a Plan of creates, restores, updates and, when pruning, deletes. A resource type whose key is
+missing from the manifest is left alone. Applying the plan re-fetches every existing resource
+and stops with ErrVersionConflict if its Version changed since the plan was made, so that
+concurrent edits are never overwritten.
+
+Tiers are checked before planning: levels must be unique, exactly one tier must be the default,
+prices must use known period types and must not exceed their original price. When not pruning,
+tiers missing from the manifest count towards the level and default checks. Tier changes are
+ordered so that levels stay unique and there is at most one default after every step: tiers
+that give up a level or the default flag are moved to a temporary level or cleared first, and
+plans that cannot be ordered this way, such as restoring a tier onto a level another tier keeps,
+are rejected.
+
+Example manifest:
+
+	products:
+	  - code: PROD001
+	    name: Premium Course
+	    price: 9900
+	  - code: BOOK001
+	    name: Printed Handbook
+	    price: 4900
+	    require_address: true
+	tiers:
+	  - code: FREE
+	    name: Free
+	    level: 1
+	    is_default: true
+	  - code: VIP
+	    name: VIP
+	    level: 2
+	    prices:
+	      - period_type: month
+	        price: 2900
+	        original_price: 3900
+	      - period_type: year
+	        price: 29900
+	        original_price: 46800
+
+Usage example:
+
+	manifest, err := catalog.LoadManifest("catalog.yaml")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	syncer := catalog.NewSyncer(client)
+	syncer.Prune = true // delete products missing from the manifest
+	plan, err := syncer.Plan(manifest)
+	if err != nil {
+		log.Fatal(err)
+	}
+	plan.WriteTo(os.Stdout)
+
+	result, err := syncer.Apply(plan)
+	if errors.Is(err, catalog.ErrVersionConflict) {
+		// Someone changed a product or tier after planning; plan again
+	}
+*/
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// ErrVersionConflict indicates a resource changed between planning and applying; Apply also
+// wraps version conflicts reported by the server with it
+var ErrVersionConflict = errors.New("catalog changed since planning")
+
+// listLimit is the page size used to read the current catalog
+const listLimit = 100
+
+// actionOrder is the order changes are applied in
+var actionOrder = map[Action]int{
+	ActionCreate:  0,
+	ActionRestore: 1,
+	ActionUpdate:  2,
+	ActionDelete:  3,
+}
+
+// Syncer plans and applies catalog changes
+type Syncer struct {
+	// Client is the WordGate API client
+	Client *wordgate.Client
+	// Prune deletes products and tiers that exist but are missing from the manifest
+	Prune bool
+	// DryRun makes Apply report the changes without performing them
+	DryRun bool
+}
+
+// NewSyncer creates a syncer that neither prunes nor runs dry
+//
+// client: The WordGate API client
+func NewSyncer(client *wordgate.Client) *Syncer {
+	return &Syncer{Client: client}
+}
+
+// ApplyResult reports the changes performed by Apply
+type ApplyResult struct {
+	// Applied are the changes performed, or that would be performed in dry-run mode
+	Applied []Change
+	// DryRun indicates no changes were performed
+	DryRun bool
+}
+
+// Plan diffs the manifest against the current catalog
+//
+// manifest: The desired catalog state
+// Returns the plan and any error
+func (s *Syncer) Plan(manifest *Manifest) (*Plan, error) {
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	for _, resource := range []func(*Manifest) ([]Change, error){s.planProducts, s.planTiers} {
+		changes, err := resource(manifest)
+		if err != nil {
+			return nil, err
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+
+	sortChanges(plan.Changes)
+	return plan, nil
+}
+
+// Apply performs the planned changes in order, stopping at the first error
+//
+// Every change to an existing resource re-fetches it first and fails with ErrVersionConflict
+// if its version differs from the one observed when planning; updates also send that version,
+// so the server rejects edits made between the check and the update. Tiers whose level or
+// default flag is taken over by another tier are first moved out of the way by a separate
+// update, so a failure part way through can leave such a tier on a temporary level until the
+// manifest is synced again. In dry-run mode the versions are still checked but nothing is
+// changed.
+//
+// plan: The plan returned by Plan
+// Returns the changes applied before any error, and the error
+func (s *Syncer) Apply(plan *Plan) (*ApplyResult, error) {
+	result := &ApplyResult{DryRun: s.DryRun}
+
+	// Tiers giving up their level or default flag move to a temporary level or stop being the
+	// default first, so that no intermediate state has two tiers on a level or two defaults
+	checked := make(map[int]bool)
+	for i, change := range plan.Changes {
+		if change.prepare == nil {
+			continue
+		}
+		if err := s.checkVersion(change); err != nil {
+			return result, err
+		}
+		checked[i] = true
+		if !s.DryRun {
+			if err := change.prepare(s); err != nil {
+				return result, fmt.Errorf("failed to prepare %s %s %s: %w", change.Action, change.Resource, change.Code, asConflict(err))
+			}
+		}
+	}
+
+	for i, change := range plan.Changes {
+		if !checked[i] {
+			if err := s.checkVersion(change); err != nil {
+				return result, err
+			}
+		}
+		if !s.DryRun {
+			if err := change.apply(s); err != nil {
+				return result, fmt.Errorf("failed to %s %s %s: %w", change.Action, change.Resource, change.Code, asConflict(err))
+			}
+		}
+		result.Applied = append(result.Applied, change)
+	}
+	return result, nil
+}
+
+// Sync plans and applies the manifest in one step
+//
+// manifest: The desired catalog state
+// Returns the plan, the apply result and any error
+func (s *Syncer) Sync(manifest *Manifest) (*Plan, *ApplyResult, error) {
+	plan, err := s.Plan(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := s.Apply(plan)
+	return plan, result, err
+}
+
+// checkVersion re-fetches the resource of a change and compares its version with the planned one
+func (s *Syncer) checkVersion(change Change) error {
+	if change.Action == ActionCreate {
+		return nil
+	}
+	var version int
+	switch change.Resource {
+	case "product":
+		product, err := s.Client.GetProduct(change.Code)
+		if err != nil {
+			return fmt.Errorf("failed to check version of product %s: %w", change.Code, err)
+		}
+		version = product.Version
+	case "tier":
+		tier, err := s.Client.GetMembershipTier(change.Code)
+		if err != nil {
+			return fmt.Errorf("failed to check version of tier %s: %w", change.Code, err)
+		}
+		version = tier.Version
+	default:
+		return fmt.Errorf("unknown resource: %s", change.Resource)
+	}
+	if version != change.Version {
+		return fmt.Errorf("%s %s: planned version %d, current version %d: %w",
+			change.Resource, change.Code, change.Version, version, ErrVersionConflict)
+	}
+	return nil
+}
+
+// asConflict wraps a version conflict reported by the server with ErrVersionConflict
+func asConflict(err error) error {
+	if errors.Is(err, wordgate.ErrVersionConflict) {
+		return fmt.Errorf("%w: %w", ErrVersionConflict, err)
+	}
+	return err
+}
+
+// sortChanges orders changes by action, then resource and code
+func sortChanges(changes []Change) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if actionOrder[a.Action] != actionOrder[b.Action] {
+			return actionOrder[a.Action] < actionOrder[b.Action]
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		return a.Code < b.Code
+	})
+}
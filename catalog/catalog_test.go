@@ -0,0 +1,136 @@
+package catalog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+func TestPlanOrdersChangesByAction(t *testing.T) {
+	server := newFakeServer(t)
+	deleted := time.Now()
+	server.addProduct(wordgate.Product{Code: "A", Name: "A", Price: 100})
+	server.addProduct(wordgate.Product{Code: "B", Name: "B", Price: 100, DeletedAt: &deleted})
+	server.addProduct(wordgate.Product{Code: "D", Name: "D", Price: 100})
+	server.addProduct(wordgate.Product{Code: "E", Name: "E", Price: 100})
+
+	syncer := NewSyncer(server.client())
+	syncer.Prune = true
+	plan, err := syncer.Plan(&Manifest{Products: []ProductSpec{
+		{Code: "E", Name: "E", Price: 100},
+		{Code: "C", Name: "C", Price: 300},
+		{Code: "B", Name: "B", Price: 200},
+		{Code: "A", Name: "A", Price: 150},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"+ product C (name: C, price: 300, require_address: false)",
+		"^ product B (price: 100 -> 200)",
+		"~ product A (price: 100 -> 150)",
+		"- product D",
+	}
+	var got []string
+	for _, change := range plan.Changes {
+		got = append(got, change.String())
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("plan =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	result, err := syncer.Apply(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Applied) != 4 {
+		t.Fatalf("applied %d changes, want 4", len(result.Applied))
+	}
+	wantWrites := "create product C,restore product B,update product B,update product A,delete product D"
+	if got := strings.Join(server.writes, ","); got != wantWrites {
+		t.Fatalf("writes = %s, want %s", got, wantWrites)
+	}
+
+	// A second plan has nothing left to do
+	plan, err = syncer.Plan(&Manifest{Products: []ProductSpec{
+		{Code: "E", Name: "E", Price: 100},
+		{Code: "C", Name: "C", Price: 300},
+		{Code: "B", Name: "B", Price: 200},
+		{Code: "A", Name: "A", Price: 150},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("second plan is not empty:\n%s", plan)
+	}
+}
+
+func TestApplyDetectsChangesSincePlanning(t *testing.T) {
+	server := newFakeServer(t)
+	server.addProduct(wordgate.Product{Code: "A", Name: "A", Price: 100})
+	server.addProduct(wordgate.Product{Code: "B", Name: "B", Price: 100})
+
+	syncer := NewSyncer(server.client())
+	manifest := &Manifest{Products: []ProductSpec{
+		{Code: "A", Name: "A", Price: 200},
+		{Code: "B", Name: "B", Price: 200},
+	}}
+	plan, err := syncer.Plan(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Edited after planning: caught by the version check before applying
+	server.products["A"].Version++
+	result, err := syncer.Apply(plan)
+	if !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("Apply() error = %v, want ErrVersionConflict", err)
+	}
+	if len(result.Applied) != 0 || len(server.writes) != 0 {
+		t.Fatalf("applied %v and wrote %v after a conflict", result.Applied, server.writes)
+	}
+
+	// Edited between the version check and the update: rejected by the server
+	plan, err = syncer.Plan(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.onGet = func(code string) {
+		if code == "A" {
+			server.products["A"].Version++
+		}
+	}
+	_, err = syncer.Apply(plan)
+	if !errors.Is(err, ErrVersionConflict) || !errors.Is(err, wordgate.ErrVersionConflict) {
+		t.Fatalf("Apply() error = %v, want ErrVersionConflict wrapping wordgate.ErrVersionConflict", err)
+	}
+	if len(server.writes) != 0 {
+		t.Fatalf("wrote %v after a server-side conflict", server.writes)
+	}
+}
+
+func TestApplyDryRunChangesNothing(t *testing.T) {
+	server := newFakeServer(t)
+	server.addProduct(wordgate.Product{Code: "A", Name: "A", Price: 100})
+
+	syncer := NewSyncer(server.client())
+	syncer.DryRun = true
+	_, result, err := syncer.Sync(&Manifest{Products: []ProductSpec{
+		{Code: "A", Name: "A", Price: 200},
+		{Code: "B", Name: "B", Price: 200},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.DryRun || len(result.Applied) != 2 {
+		t.Fatalf("result = %+v, want 2 dry-run changes", result)
+	}
+	if len(server.writes) != 0 {
+		t.Fatalf("dry run wrote %v", server.writes)
+	}
+}
@@ -0,0 +1,175 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest is the desired state of an application's catalog
+type Manifest struct {
+	// Products are the products that should exist
+	Products []ProductSpec `json:"products" yaml:"products"`
+	// Tiers are the membership tiers that should exist
+	Tiers []TierSpec `json:"tiers" yaml:"tiers"`
+}
+
+// ProductSpec is the desired state of a product
+type ProductSpec struct {
+	// Code is the unique product code
+	Code string `json:"code" yaml:"code"`
+	// Name is the product name
+	Name string `json:"name" yaml:"name"`
+	// Price is the product price in cents
+	Price int64 `json:"price" yaml:"price"`
+	// RequireAddress indicates whether this product requires shipping address
+	RequireAddress bool `json:"require_address" yaml:"require_address"`
+}
+
+// TierSpec is the desired state of a membership tier
+type TierSpec struct {
+	// Code is the unique tier code
+	Code string `json:"code" yaml:"code"`
+	// Name is the tier name
+	Name string `json:"name" yaml:"name"`
+	// Level is the tier level (higher number = higher tier)
+	Level int `json:"level" yaml:"level"`
+	// IsDefault indicates whether this is the default tier
+	IsDefault bool `json:"is_default" yaml:"is_default"`
+	// Prices are the pricing options, at most one per period type
+	Prices []PriceSpec `json:"prices" yaml:"prices"`
+}
+
+// PriceSpec is the desired price of a membership tier for one period
+type PriceSpec struct {
+	// PeriodType is the membership period type
+	PeriodType wordgate.MembershipPeriodType `json:"period_type" yaml:"period_type"`
+	// Price is the discounted price in cents
+	Price int64 `json:"price" yaml:"price"`
+	// OriginalPrice is the original price in cents
+	OriginalPrice int64 `json:"original_price" yaml:"original_price"`
+}
+
+// LoadManifest reads a manifest file, decoding files ending in .json as JSON and others as YAML
+//
+// path: The manifest file path
+// Returns the validated manifest and any error
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	format := "yaml"
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		format = "json"
+	}
+	manifest, err := ParseManifest(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// ParseManifest decodes and validates a manifest
+//
+// data: The manifest content
+// format: "yaml" or "json"
+// Returns the validated manifest and any error
+func ParseManifest(data []byte, format string) (*Manifest, error) {
+	var manifest Manifest
+	switch format {
+	case "yaml", "yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML manifest: %w", err)
+		}
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON manifest: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported manifest format: %s", format)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// Validate checks the manifest invariants
+//
+// Every product needs a unique code, a name and a non-negative price. Every tier needs a unique
+// code, a name and a unique level of at least 1, exactly one tier must be the default, and every
+// price needs a known period type, used once per tier, and must not exceed its original price.
+func (m *Manifest) Validate() error {
+	seen := make(map[string]bool, len(m.Products))
+	for i, product := range m.Products {
+		switch {
+		case product.Code == "":
+			return fmt.Errorf("invalid manifest: products[%d]: code is required", i)
+		case seen[product.Code]:
+			return fmt.Errorf("invalid manifest: duplicate product code %s", product.Code)
+		case product.Name == "":
+			return fmt.Errorf("invalid manifest: product %s: name is required", product.Code)
+		case product.Price < 0:
+			return fmt.Errorf("invalid manifest: product %s: price must not be negative", product.Code)
+		}
+		seen[product.Code] = true
+	}
+	return validateTiers(m.Tiers)
+}
+
+// validateTiers checks the tier invariants of a manifest
+func validateTiers(tiers []TierSpec) error {
+	codes := make(map[string]bool, len(tiers))
+	levels := make(map[int]string, len(tiers))
+	defaults := 0
+	for i, tier := range tiers {
+		switch {
+		case tier.Code == "":
+			return fmt.Errorf("invalid manifest: tiers[%d]: code is required", i)
+		case codes[tier.Code]:
+			return fmt.Errorf("invalid manifest: duplicate tier code %s", tier.Code)
+		case tier.Name == "":
+			return fmt.Errorf("invalid manifest: tier %s: name is required", tier.Code)
+		case tier.Level < 1:
+			return fmt.Errorf("invalid manifest: tier %s: level must be at least 1", tier.Code)
+		case levels[tier.Level] != "":
+			return fmt.Errorf("invalid manifest: tiers %s and %s have the same level %d", levels[tier.Level], tier.Code, tier.Level)
+		}
+		codes[tier.Code] = true
+		levels[tier.Level] = tier.Code
+		if tier.IsDefault {
+			defaults++
+		}
+
+		periods := make(map[wordgate.MembershipPeriodType]bool, len(tier.Prices))
+		for _, price := range tier.Prices {
+			switch {
+			case !price.PeriodType.IsValid():
+				return fmt.Errorf("invalid manifest: tier %s: unknown period type %q", tier.Code, price.PeriodType)
+			case periods[price.PeriodType]:
+				return fmt.Errorf("invalid manifest: tier %s: duplicate price for period %s", tier.Code, price.PeriodType)
+			case price.Price < 0:
+				return fmt.Errorf("invalid manifest: tier %s: %s price must not be negative", tier.Code, price.PeriodType)
+			case price.Price > price.OriginalPrice:
+				return fmt.Errorf("invalid manifest: tier %s: %s price %d exceeds original price %d",
+					tier.Code, price.PeriodType, price.Price, price.OriginalPrice)
+			}
+			periods[price.PeriodType] = true
+		}
+	}
+	if len(tiers) > 0 && defaults != 1 {
+		return fmt.Errorf("invalid manifest: exactly one tier must be the default, found %d", defaults)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package catalog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Action is the operation a change performs
+type Action string
+
+const (
+	// ActionCreate creates a resource that does not exist
+	ActionCreate Action = "create"
+	// ActionRestore restores a deleted resource, updating it afterwards if it differs
+	ActionRestore Action = "restore"
+	// ActionUpdate updates a resource that differs from the manifest
+	ActionUpdate Action = "update"
+	// ActionDelete deletes a resource missing from the manifest (only when pruning)
+	ActionDelete Action = "delete"
+)
+
+// symbols prefix each action in the printed plan
+var symbols = map[Action]string{
+	ActionCreate:  "+",
+	ActionRestore: "^",
+	ActionUpdate:  "~",
+	ActionDelete:  "-",
+}
+
+// FieldChange is a field that differs between the current and desired state
+type FieldChange struct {
+	// Field is the field name
+	Field string
+	// Old is the current value
+	Old string
+	// New is the desired value
+	New string
+}
+
+// Change is one planned operation
+type Change struct {
+	// Resource is the resource type (e.g., "product")
+	Resource string
+	// Action is the operation
+	Action Action
+	// Code is the resource code
+	Code string
+	// Fields are the fields that will change (empty for deletes)
+	Fields []FieldChange
+	// Version is the resource version observed when planning (0 for creates)
+	Version int
+
+	// prepare runs before any change is applied and moves a tier off the level or default
+	// flag another tier takes over (optional)
+	prepare func(s *Syncer) error
+	// apply performs the change
+	apply func(s *Syncer) error
+}
+
+// String formats the change as a plan line, e.g. "~ product PROD001 (price: 100 -> 200)"
+func (c Change) String() string {
+	line := fmt.Sprintf("%s %s %s", symbols[c.Action], c.Resource, c.Code)
+	if len(c.Fields) > 0 {
+		fields := make([]string, len(c.Fields))
+		for i, field := range c.Fields {
+			if c.Action == ActionCreate {
+				fields[i] = fmt.Sprintf("%s: %s", field.Field, field.New)
+			} else {
+				fields[i] = fmt.Sprintf("%s: %s -> %s", field.Field, field.Old, field.New)
+			}
+		}
+		line += " (" + strings.Join(fields, ", ") + ")"
+	}
+	return line
+}
+
+// Plan is the ordered set of changes that bring the catalog to the manifest
+type Plan struct {
+	// Changes are the planned changes in apply order
+	Changes []Change
+}
+
+// Empty reports whether the catalog already matches the manifest
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns the number of changes with the given action
+func (p *Plan) Count(action Action) int {
+	count := 0
+	for _, change := range p.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// WriteTo prints the plan, one change per line followed by a summary
+func (p *Plan) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	if p.Empty() {
+		b.WriteString("No changes. The catalog matches the manifest.\n")
+	} else {
+		for _, change := range p.Changes {
+			b.WriteString(change.String())
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "\nPlan: %d to create, %d to restore, %d to update, %d to delete.\n",
+			p.Count(ActionCreate), p.Count(ActionRestore), p.Count(ActionUpdate), p.Count(ActionDelete))
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// String returns the printed plan
+func (p *Plan) String() string {
+	var b strings.Builder
+	p.WriteTo(&b)
+	return b.String()
+}
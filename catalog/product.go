@@ -0,0 +1,153 @@
+package catalog
+
+import (
+	"strconv"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// planProducts plans the product changes of a manifest; products are left alone when the
+// manifest has no products key
+func (s *Syncer) planProducts(manifest *Manifest) ([]Change, error) {
+	if manifest.Products == nil {
+		return nil, nil
+	}
+	products, err := s.listProducts()
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]*wordgate.Product, len(products))
+	for i := range products {
+		current[products[i].Code] = &products[i]
+	}
+
+	var changes []Change
+	desired := make(map[string]bool, len(manifest.Products))
+	for _, spec := range manifest.Products {
+		desired[spec.Code] = true
+		if change, ok := planProduct(spec, current[spec.Code]); ok {
+			changes = append(changes, change)
+		}
+	}
+	if s.Prune {
+		for _, product := range products {
+			if desired[product.Code] || product.DeletedAt != nil {
+				continue
+			}
+			code := product.Code
+			changes = append(changes, Change{
+				Resource: "product",
+				Action:   ActionDelete,
+				Code:     code,
+				Version:  product.Version,
+				apply: func(s *Syncer) error {
+					return s.Client.DeleteProduct(code)
+				},
+			})
+		}
+	}
+	return changes, nil
+}
+
+// listProducts reads all products, including deleted ones
+func (s *Syncer) listProducts() ([]wordgate.Product, error) {
+	var products []wordgate.Product
+	for page := 1; ; page++ {
+		result, err := s.Client.ListProducts(&wordgate.ListProductsRequest{
+			ShowDeleted: true,
+			Page:        page,
+			Limit:       listLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, result.Data...)
+		if len(result.Data) < listLimit || page >= result.Pagination.TotalPages {
+			return products, nil
+		}
+	}
+}
+
+// planProduct returns the change that brings a product to its spec, if any
+func planProduct(spec ProductSpec, current *wordgate.Product) (Change, bool) {
+	change := Change{Resource: "product", Code: spec.Code}
+
+	if current == nil {
+		change.Action = ActionCreate
+		change.Fields = []FieldChange{
+			{Field: "name", New: spec.Name},
+			{Field: "price", New: strconv.FormatInt(spec.Price, 10)},
+			{Field: "require_address", New: strconv.FormatBool(spec.RequireAddress)},
+		}
+		change.apply = func(s *Syncer) error {
+			_, err := s.Client.CreateProduct(&wordgate.CreateProductRequest{
+				Code:           spec.Code,
+				Name:           spec.Name,
+				Price:          spec.Price,
+				RequireAddress: spec.RequireAddress,
+			})
+			return err
+		}
+		return change, true
+	}
+
+	change.Version = current.Version
+	change.Fields = diffProduct(current, spec)
+	// update sends the version the product is expected to have, so the server rejects concurrent edits
+	update := func(s *Syncer, version int) error {
+		_, err := s.Client.UpdateProduct(spec.Code, &wordgate.UpdateProductRequest{
+			Version:        wordgate.Ptr(version),
+			Name:           spec.Name,
+			Price:          spec.Price,
+			RequireAddress: spec.RequireAddress,
+		})
+		return err
+	}
+
+	switch {
+	case current.DeletedAt != nil:
+		change.Action = ActionRestore
+		needsUpdate := len(change.Fields) > 0
+		change.apply = func(s *Syncer) error {
+			restored, err := s.Client.RestoreProduct(spec.Code)
+			if err != nil {
+				return err
+			}
+			if needsUpdate {
+				return update(s, restored.Version)
+			}
+			return nil
+		}
+	case len(change.Fields) > 0:
+		change.Action = ActionUpdate
+		change.apply = func(s *Syncer) error {
+			return update(s, current.Version)
+		}
+	default:
+		return Change{}, false
+	}
+	return change, true
+}
+
+// diffProduct returns the fields that differ between a product and its spec
+func diffProduct(current *wordgate.Product, spec ProductSpec) []FieldChange {
+	var fields []FieldChange
+	if current.Name != spec.Name {
+		fields = append(fields, FieldChange{Field: "name", Old: current.Name, New: spec.Name})
+	}
+	if current.Price != spec.Price {
+		fields = append(fields, FieldChange{
+			Field: "price",
+			Old:   strconv.FormatInt(current.Price, 10),
+			New:   strconv.FormatInt(spec.Price, 10),
+		})
+	}
+	if current.RequireAddress != spec.RequireAddress {
+		fields = append(fields, FieldChange{
+			Field: "require_address",
+			Old:   strconv.FormatBool(current.RequireAddress),
+			New:   strconv.FormatBool(spec.RequireAddress),
+		})
+	}
+	return fields
+}
@@ -0,0 +1,233 @@
+package catalog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// fakeServer is an in-memory WordGate products and membership tiers API
+//
+// Every write increments the resource version, updates carrying a stale version are rejected
+// with ErrVersionConflict, and active tiers must keep unique levels and at most one default.
+type fakeServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	products map[string]*wordgate.Product
+	tiers    map[string]*wordgate.MembershipTier
+	writes   []string
+	// onGet runs after a product or tier is read, e.g. to simulate a concurrent edit
+	onGet func(code string)
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+	s := &fakeServer{
+		products: make(map[string]*wordgate.Product),
+		tiers:    make(map[string]*wordgate.MembershipTier),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeServer) client() *wordgate.Client {
+	return wordgate.NewClient("app", "secret", s.URL)
+}
+
+func (s *fakeServer) addProduct(product wordgate.Product) {
+	if product.Version == 0 {
+		product.Version = 1
+	}
+	s.products[product.Code] = &product
+}
+
+func (s *fakeServer) addTier(tier wordgate.MembershipTier) {
+	if tier.Version == 0 {
+		tier.Version = 1
+	}
+	s.tiers[tier.Code] = &tier
+}
+
+func (s *fakeServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/app/")
+	switch {
+	case strings.HasPrefix(path, "products"):
+		s.serveProducts(w, r, strings.Trim(strings.TrimPrefix(path, "products"), "/"))
+	case strings.HasPrefix(path, "membership/tiers"):
+		s.serveTiers(w, r, strings.Trim(strings.TrimPrefix(path, "membership/tiers"), "/"))
+	default:
+		writeFakeError(w, http.StatusNotFound, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *fakeServer) serveProducts(w http.ResponseWriter, r *http.Request, rest string) {
+	code, action, _ := strings.Cut(rest, "/")
+	if code == "" {
+		if r.Method == http.MethodPost {
+			var request wordgate.CreateProductRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			s.writes = append(s.writes, "create product "+request.Code)
+			product := &wordgate.Product{Code: request.Code, Name: request.Name, Price: request.Price, RequireAddress: request.RequireAddress, Status: wordgate.ProductStatusActive, Version: 1}
+			s.products[request.Code] = product
+			writeFakeData(w, product)
+			return
+		}
+		var products []wordgate.Product
+		for _, product := range s.products {
+			products = append(products, *product)
+		}
+		writeFakeData(w, wordgate.ProductListResponse{Data: products, Pagination: wordgate.PaginationInfo{CurrentPage: 1, TotalPages: 1, Total: int64(len(products))}})
+		return
+	}
+
+	product, ok := s.products[code]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, http.StatusNotFound, "product not found")
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet:
+		writeFakeData(w, product)
+		if s.onGet != nil {
+			s.onGet(code)
+		}
+	case r.Method == http.MethodPut:
+		var request wordgate.UpdateProductRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.Version != nil && *request.Version != product.Version {
+			writeFakeError(w, http.StatusConflict, wordgate.ErrVersionConflict.Code, "version conflict")
+			return
+		}
+		s.writes = append(s.writes, "update product "+code)
+		product.Name, product.Price, product.RequireAddress = request.Name, request.Price, request.RequireAddress
+		product.Version++
+		writeFakeData(w, product)
+	case r.Method == http.MethodDelete:
+		s.writes = append(s.writes, "delete product "+code)
+		now := time.Now()
+		product.DeletedAt = &now
+		product.Version++
+		writeFakeData(w, map[string]any{})
+	case action == "restore":
+		s.writes = append(s.writes, "restore product "+code)
+		product.DeletedAt = nil
+		product.Version++
+		writeFakeData(w, product)
+	}
+}
+
+func (s *fakeServer) serveTiers(w http.ResponseWriter, r *http.Request, rest string) {
+	code, action, _ := strings.Cut(rest, "/")
+	if code == "" {
+		if r.Method == http.MethodPost {
+			var request wordgate.CreateMembershipTierRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			tier := &wordgate.MembershipTier{Code: request.Code, Name: request.Name, Level: request.Level, IsDefault: request.IsDefault, Status: wordgate.MembershipTierStatusActive, Version: 1, Prices: fakePrices(request.Prices)}
+			if msg := s.checkTier(tier); msg != "" {
+				writeFakeError(w, http.StatusConflict, http.StatusConflict, msg)
+				return
+			}
+			s.writes = append(s.writes, "create tier "+request.Code)
+			s.tiers[request.Code] = tier
+			writeFakeData(w, tier)
+			return
+		}
+		var tiers []wordgate.MembershipTier
+		for _, tier := range s.tiers {
+			tiers = append(tiers, *tier)
+		}
+		writeFakeData(w, wordgate.MembershipTierListResponse{Data: tiers, Pagination: wordgate.PaginationInfo{CurrentPage: 1, TotalPages: 1, Total: int64(len(tiers))}})
+		return
+	}
+
+	tier, ok := s.tiers[code]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, http.StatusNotFound, "tier not found")
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet:
+		writeFakeData(w, tier)
+		if s.onGet != nil {
+			s.onGet(code)
+		}
+	case r.Method == http.MethodPut:
+		var request wordgate.UpdateMembershipTierRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.Version != nil && *request.Version != tier.Version {
+			writeFakeError(w, http.StatusConflict, wordgate.ErrVersionConflict.Code, "version conflict")
+			return
+		}
+		updated := *tier
+		updated.Name, updated.Level, updated.IsDefault, updated.Prices = request.Name, request.Level, request.IsDefault, fakePrices(request.Prices)
+		if msg := s.checkTier(&updated); msg != "" {
+			writeFakeError(w, http.StatusConflict, http.StatusConflict, msg)
+			return
+		}
+		s.writes = append(s.writes, "update tier "+code)
+		updated.Version++
+		*tier = updated
+		writeFakeData(w, tier)
+	case r.Method == http.MethodDelete:
+		s.writes = append(s.writes, "delete tier "+code)
+		now := time.Now()
+		tier.DeletedAt = &now
+		tier.Version++
+		writeFakeData(w, map[string]any{})
+	case action == "restore":
+		restored := *tier
+		restored.DeletedAt = nil
+		if msg := s.checkTier(&restored); msg != "" {
+			writeFakeError(w, http.StatusConflict, http.StatusConflict, msg)
+			return
+		}
+		s.writes = append(s.writes, "restore tier "+code)
+		restored.Version++
+		*tier = restored
+		writeFakeData(w, tier)
+	}
+}
+
+// checkTier returns why the tier would break the level or default invariants, if it would
+func (s *fakeServer) checkTier(tier *wordgate.MembershipTier) string {
+	for _, other := range s.tiers {
+		if other.Code == tier.Code || other.DeletedAt != nil {
+			continue
+		}
+		if other.Level == tier.Level {
+			return "level already used by tier " + other.Code
+		}
+		if tier.IsDefault && other.IsDefault {
+			return "tier " + other.Code + " is already the default"
+		}
+	}
+	return ""
+}
+
+func fakePrices(requests []wordgate.MembershipPriceRequest) []wordgate.MembershipPrice {
+	prices := make([]wordgate.MembershipPrice, len(requests))
+	for i, request := range requests {
+		prices[i] = wordgate.MembershipPrice{PeriodType: request.PeriodType, Price: request.Price, OriginalPrice: request.OriginalPrice}
+	}
+	return prices
+}
+
+func writeFakeData(w http.ResponseWriter, data any) {
+	json.NewEncoder(w).Encode(wordgate.APIResponse{Code: 0, Data: data})
+}
+
+func writeFakeError(w http.ResponseWriter, status, code int, msg string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(wordgate.APIResponse{Code: code, Msg: msg})
+}
@@ -0,0 +1,390 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// planTiers plans the tier changes of a manifest; tiers are left alone when the manifest has
+// no tiers key
+func (s *Syncer) planTiers(manifest *Manifest) ([]Change, error) {
+	if manifest.Tiers == nil {
+		return nil, nil
+	}
+	tiers, err := s.listTiers()
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]*wordgate.MembershipTier, len(tiers))
+	for i := range tiers {
+		current[tiers[i].Code] = &tiers[i]
+	}
+
+	var changes []Change
+	stages := stageTiers(manifest.Tiers, tiers, s.Prune)
+	desired := make(map[string]bool, len(manifest.Tiers))
+	for _, spec := range manifest.Tiers {
+		desired[spec.Code] = true
+		if change, ok := planTier(spec, current[spec.Code], stages); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	// Tiers that stay but are not in the manifest must not break the level and default invariants
+	levels := make(map[int]string, len(manifest.Tiers))
+	for _, spec := range manifest.Tiers {
+		levels[spec.Level] = spec.Code
+	}
+	for _, tier := range tiers {
+		if desired[tier.Code] || tier.DeletedAt != nil {
+			continue
+		}
+		if s.Prune {
+			code := tier.Code
+			change := Change{
+				Resource: "tier",
+				Action:   ActionDelete,
+				Code:     code,
+				Version:  tier.Version,
+				apply: func(s *Syncer) error {
+					return s.Client.DeleteMembershipTier(code)
+				},
+			}
+			if stage, ok := stages[code]; ok {
+				stageTier(&change, current[code], stage, func(s *Syncer, version int) error {
+					return s.Client.DeleteMembershipTier(code)
+				})
+			}
+			changes = append(changes, change)
+			continue
+		}
+		if other, ok := levels[tier.Level]; ok {
+			return nil, fmt.Errorf("invalid catalog: tier %s has the same level %d as unmanaged tier %s", other, tier.Level, tier.Code)
+		}
+		if tier.IsDefault {
+			return nil, fmt.Errorf("invalid catalog: unmanaged tier %s is also the default; add it to the manifest or enable pruning", tier.Code)
+		}
+	}
+
+	if err := checkTierOrder(changes, manifest.Tiers, tiers, stages); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// tierStage is the temporary level and default flag of a tier that gives up its level or
+// default flag to another tier
+type tierStage struct {
+	Level     int
+	IsDefault bool
+}
+
+// stageTiers returns the temporary state of every active tier whose level is taken over by
+// another tier or that stops being the default; temporary levels lie above all existing and
+// planned levels
+func stageTiers(specs []TierSpec, tiers []wordgate.MembershipTier, prune bool) map[string]tierStage {
+	byCode := make(map[string]TierSpec, len(specs))
+	claims := make(map[int]map[string]bool)
+	claim := func(level int, code string) {
+		if claims[level] == nil {
+			claims[level] = make(map[string]bool)
+		}
+		claims[level][code] = true
+	}
+	next := 0
+	for _, spec := range specs {
+		byCode[spec.Code] = spec
+		claim(spec.Level, spec.Code)
+		next = max(next, spec.Level)
+	}
+	for _, tier := range tiers {
+		next = max(next, tier.Level)
+		// A restored tier briefly comes back on its old level
+		if _, ok := byCode[tier.Code]; ok && tier.DeletedAt != nil {
+			claim(tier.Level, tier.Code)
+		}
+	}
+
+	stages := make(map[string]tierStage)
+	for _, tier := range tiers {
+		spec, managed := byCode[tier.Code]
+		if tier.DeletedAt != nil || (!managed && !prune) {
+			continue
+		}
+		stage := tierStage{Level: tier.Level, IsDefault: tier.IsDefault}
+		if !managed || spec.Level != tier.Level {
+			for code := range claims[tier.Level] {
+				if code != tier.Code {
+					next++
+					stage.Level = next
+					break
+				}
+			}
+		}
+		if tier.IsDefault && (!managed || !spec.IsDefault) {
+			stage.IsDefault = false
+		}
+		if stage != (tierStage{Level: tier.Level, IsDefault: tier.IsDefault}) {
+			stages[tier.Code] = stage
+		}
+	}
+	return stages
+}
+
+// stageTier makes a change first move its tier to the staged level and default flag, then
+// apply with the version the tier has after that
+func stageTier(change *Change, current *wordgate.MembershipTier, stage tierStage, apply func(s *Syncer, version int) error) {
+	prices := make([]wordgate.MembershipPriceRequest, len(current.Prices))
+	for i, price := range current.Prices {
+		prices[i] = wordgate.MembershipPriceRequest{
+			PeriodType:    price.PeriodType,
+			Price:         price.Price,
+			OriginalPrice: price.OriginalPrice,
+		}
+	}
+	version := current.Version
+	change.prepare = func(s *Syncer) error {
+		staged, err := s.Client.UpdateMembershipTier(current.Code, &wordgate.UpdateMembershipTierRequest{
+			Version:   wordgate.Ptr(current.Version),
+			Name:      current.Name,
+			Level:     stage.Level,
+			IsDefault: stage.IsDefault,
+			Prices:    prices,
+		})
+		if err != nil {
+			return err
+		}
+		version = staged.Version
+		return nil
+	}
+	change.apply = func(s *Syncer) error {
+		return apply(s, version)
+	}
+}
+
+// checkTierOrder replays the tier changes in apply order, after staging, and rejects the plan
+// if a step would put two tiers on the same level or make two tiers the default
+func checkTierOrder(changes []Change, specs []TierSpec, tiers []wordgate.MembershipTier, stages map[string]tierStage) error {
+	desired := make(map[string]tierStage, len(specs))
+	for _, spec := range specs {
+		desired[spec.Code] = tierStage{Level: spec.Level, IsDefault: spec.IsDefault}
+	}
+	previous := make(map[string]tierStage, len(tiers))
+	state := make(map[string]tierStage, len(tiers))
+	for _, tier := range tiers {
+		previous[tier.Code] = tierStage{Level: tier.Level, IsDefault: tier.IsDefault}
+		if tier.DeletedAt == nil {
+			state[tier.Code] = previous[tier.Code]
+		}
+	}
+	for code, stage := range stages {
+		state[code] = stage
+	}
+
+	// set moves a tier to a new state and checks it against all other active tiers
+	set := func(change Change, next tierStage) error {
+		state[change.Code] = next
+		for code, other := range state {
+			if code == change.Code {
+				continue
+			}
+			if other.Level == next.Level {
+				return fmt.Errorf("invalid catalog: cannot %s tier %s while tier %s still has level %d",
+					change.Action, change.Code, code, next.Level)
+			}
+			if other.IsDefault && next.IsDefault {
+				return fmt.Errorf("invalid catalog: cannot %s tier %s while tier %s is still the default",
+					change.Action, change.Code, code)
+			}
+		}
+		return nil
+	}
+
+	ordered := append([]Change(nil), changes...)
+	sortChanges(ordered)
+	for _, change := range ordered {
+		var err error
+		switch change.Action {
+		case ActionCreate, ActionUpdate:
+			err = set(change, desired[change.Code])
+		case ActionRestore:
+			if err = set(change, previous[change.Code]); err == nil {
+				err = set(change, desired[change.Code])
+			}
+		case ActionDelete:
+			delete(state, change.Code)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listTiers reads all membership tiers, including deleted ones
+func (s *Syncer) listTiers() ([]wordgate.MembershipTier, error) {
+	var tiers []wordgate.MembershipTier
+	for page := 1; ; page++ {
+		result, err := s.Client.ListMembershipTiers(&wordgate.ListMembershipTiersRequest{
+			ShowDeleted: true,
+			Page:        page,
+			Limit:       listLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, result.Data...)
+		if len(result.Data) < listLimit || page >= result.Pagination.TotalPages {
+			return tiers, nil
+		}
+	}
+}
+
+// planTier returns the change that brings a tier to its spec, if any; an update of a staged
+// tier first moves it to its temporary state
+func planTier(spec TierSpec, current *wordgate.MembershipTier, stages map[string]tierStage) (Change, bool) {
+	change := Change{Resource: "tier", Code: spec.Code}
+	prices := make([]wordgate.MembershipPriceRequest, len(spec.Prices))
+	for i, price := range spec.Prices {
+		prices[i] = wordgate.MembershipPriceRequest{
+			PeriodType:    price.PeriodType,
+			Price:         price.Price,
+			OriginalPrice: price.OriginalPrice,
+		}
+	}
+
+	if current == nil {
+		change.Action = ActionCreate
+		change.Fields = []FieldChange{
+			{Field: "name", New: spec.Name},
+			{Field: "level", New: strconv.Itoa(spec.Level)},
+			{Field: "is_default", New: strconv.FormatBool(spec.IsDefault)},
+		}
+		for _, price := range spec.Prices {
+			change.Fields = append(change.Fields, FieldChange{Field: "price." + string(price.PeriodType), New: formatPrice(price)})
+		}
+		change.apply = func(s *Syncer) error {
+			_, err := s.Client.CreateMembershipTier(&wordgate.CreateMembershipTierRequest{
+				Code:      spec.Code,
+				Name:      spec.Name,
+				Level:     spec.Level,
+				IsDefault: spec.IsDefault,
+				Prices:    prices,
+			})
+			return err
+		}
+		return change, true
+	}
+
+	change.Version = current.Version
+	change.Fields = diffTier(current, spec)
+	// update sends the version the tier is expected to have, so the server rejects concurrent edits
+	update := func(s *Syncer, version int) error {
+		_, err := s.Client.UpdateMembershipTier(spec.Code, &wordgate.UpdateMembershipTierRequest{
+			Version:   wordgate.Ptr(version),
+			Name:      spec.Name,
+			Level:     spec.Level,
+			IsDefault: spec.IsDefault,
+			Prices:    prices,
+		})
+		return err
+	}
+
+	switch {
+	case current.DeletedAt != nil:
+		change.Action = ActionRestore
+		needsUpdate := len(change.Fields) > 0
+		change.apply = func(s *Syncer) error {
+			restored, err := s.Client.RestoreMembershipTier(spec.Code)
+			if err != nil {
+				return err
+			}
+			if needsUpdate {
+				return update(s, restored.Version)
+			}
+			return nil
+		}
+	case len(change.Fields) > 0:
+		change.Action = ActionUpdate
+		change.apply = func(s *Syncer) error {
+			return update(s, current.Version)
+		}
+		if stage, ok := stages[spec.Code]; ok {
+			stageTier(&change, current, stage, update)
+		}
+	default:
+		return Change{}, false
+	}
+	return change, true
+}
+
+// diffTier returns the fields and per-period prices that differ between a tier and its spec
+func diffTier(current *wordgate.MembershipTier, spec TierSpec) []FieldChange {
+	var fields []FieldChange
+	if current.Name != spec.Name {
+		fields = append(fields, FieldChange{Field: "name", Old: current.Name, New: spec.Name})
+	}
+	if current.Level != spec.Level {
+		fields = append(fields, FieldChange{Field: "level", Old: strconv.Itoa(current.Level), New: strconv.Itoa(spec.Level)})
+	}
+	if current.IsDefault != spec.IsDefault {
+		fields = append(fields, FieldChange{
+			Field: "is_default",
+			Old:   strconv.FormatBool(current.IsDefault),
+			New:   strconv.FormatBool(spec.IsDefault),
+		})
+	}
+
+	old := make(map[wordgate.MembershipPeriodType]string, len(current.Prices))
+	for _, price := range current.Prices {
+		old[price.PeriodType] = formatPrice(PriceSpec{
+			PeriodType:    price.PeriodType,
+			Price:         price.Price,
+			OriginalPrice: price.OriginalPrice,
+		})
+	}
+	desired := make(map[wordgate.MembershipPeriodType]string, len(spec.Prices))
+	for _, price := range spec.Prices {
+		desired[price.PeriodType] = formatPrice(price)
+	}
+
+	periods := make([]wordgate.MembershipPeriodType, 0, len(old)+len(desired))
+	for period := range old {
+		periods = append(periods, period)
+	}
+	for period := range desired {
+		if _, ok := old[period]; !ok {
+			periods = append(periods, period)
+		}
+	}
+	// Shorter periods first
+	sort.Slice(periods, func(i, j int) bool {
+		return wordgate.GetMonthsByPeriodType(periods[i]) < wordgate.GetMonthsByPeriodType(periods[j])
+	})
+	for _, period := range periods {
+		if old[period] != desired[period] {
+			fields = append(fields, FieldChange{
+				Field: "price." + string(period),
+				Old:   orNone(old[period]),
+				New:   orNone(desired[period]),
+			})
+		}
+	}
+	return fields
+}
+
+// formatPrice formats a price as "price/original"
+func formatPrice(price PriceSpec) string {
+	return fmt.Sprintf("%d/%d", price.Price, price.OriginalPrice)
+}
+
+// orNone returns "none" for an empty value
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
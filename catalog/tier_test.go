@@ -0,0 +1,82 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// syncTiers plans and applies a tier manifest against the fake server and checks the result
+func syncTiers(t *testing.T, server *fakeServer, prune bool, specs ...TierSpec) {
+	t.Helper()
+	syncer := NewSyncer(server.client())
+	syncer.Prune = prune
+	if _, _, err := syncer.Sync(&Manifest{Tiers: specs}); err != nil {
+		t.Fatalf("Sync() error = %v (writes: %v)", err, server.writes)
+	}
+	for _, spec := range specs {
+		tier := server.tiers[spec.Code]
+		if tier == nil || tier.DeletedAt != nil || tier.Level != spec.Level || tier.IsDefault != spec.IsDefault {
+			t.Fatalf("tier %s = %+v, want level %d default %v", spec.Code, tier, spec.Level, spec.IsDefault)
+		}
+	}
+	plan, err := syncer.Plan(&Manifest{Tiers: specs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("plan after sync is not empty:\n%s", plan)
+	}
+}
+
+func TestSyncSwapsTierLevels(t *testing.T) {
+	server := newFakeServer(t)
+	server.addTier(wordgate.MembershipTier{Code: "FREE", Name: "Free", Level: 1, IsDefault: true})
+	server.addTier(wordgate.MembershipTier{Code: "GOLD", Name: "Gold", Level: 2})
+	server.addTier(wordgate.MembershipTier{Code: "VIP", Name: "VIP", Level: 3})
+
+	syncTiers(t, server, false,
+		TierSpec{Code: "FREE", Name: "Free", Level: 1, IsDefault: true},
+		TierSpec{Code: "GOLD", Name: "Gold", Level: 3},
+		TierSpec{Code: "VIP", Name: "VIP", Level: 2},
+	)
+}
+
+func TestSyncMovesDefaultToNewTier(t *testing.T) {
+	server := newFakeServer(t)
+	server.addTier(wordgate.MembershipTier{Code: "FREE", Name: "Free", Level: 1, IsDefault: true})
+
+	syncTiers(t, server, false,
+		TierSpec{Code: "BASIC", Name: "Basic", Level: 1, IsDefault: true},
+		TierSpec{Code: "FREE", Name: "Free", Level: 2},
+	)
+}
+
+func TestSyncPrunedTierReleasesLevelAndDefault(t *testing.T) {
+	server := newFakeServer(t)
+	server.addTier(wordgate.MembershipTier{Code: "OLD", Name: "Old", Level: 1, IsDefault: true})
+
+	syncTiers(t, server, true, TierSpec{Code: "NEW", Name: "New", Level: 1, IsDefault: true})
+	if server.tiers["OLD"].DeletedAt == nil {
+		t.Fatal("tier OLD was not deleted")
+	}
+}
+
+func TestPlanRejectsRestoreOntoTakenLevel(t *testing.T) {
+	server := newFakeServer(t)
+	deleted := time.Now()
+	server.addTier(wordgate.MembershipTier{Code: "FREE", Name: "Free", Level: 1, IsDefault: true})
+	server.addTier(wordgate.MembershipTier{Code: "GOLD", Name: "Gold", Level: 2})
+	server.addTier(wordgate.MembershipTier{Code: "VIP", Name: "VIP", Level: 2, DeletedAt: &deleted})
+
+	_, err := NewSyncer(server.client()).Plan(&Manifest{Tiers: []TierSpec{
+		{Code: "FREE", Name: "Free", Level: 1, IsDefault: true},
+		{Code: "GOLD", Name: "Gold", Level: 2},
+		{Code: "VIP", Name: "VIP", Level: 3},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "restore tier VIP") {
+		t.Fatalf("Plan() error = %v, want an error about restoring VIP", err)
+	}
+}
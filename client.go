@@ -11,10 +11,7 @@ Basic usage examples:
 
 	// Create an app product order (admin API)
 	productOrder, err := client.CreateAppProductOrder(&wordgate.CreateAppProductOrderRequest{
-		Items: []struct {
-			ItemCode string `json:"item_code"`
-			Quantity int    `json:"quantity"`
-		}{
+		Items: []wordgate.OrderItem{
 			{
 				ItemCode: "PRODUCT001",
 				Quantity: 1,
@@ -41,10 +38,11 @@ Basic usage examples:
 
 	fmt.Printf("App membership order created: %s\n", membershipOrder.OrderNo)
 
-	// Create a membership order (client API)
-	clientMembershipOrder, err := client.CreateMembershipOrder(&wordgate.CreateMembershipOrderRequest{
+	// Create a membership order as a signed-in end user (client API, no app secret)
+	userClient := wordgate.NewUserClient("your-app-code", "user-access-token", "https://api.wordgate.example.com")
+	clientMembershipOrder, err := userClient.CreateMembershipOrder(&wordgate.CreateMembershipOrderRequest{
 		TierID:     1,
-		PeriodType: "month",
+		PeriodType: wordgate.PeriodTypeMonth,
 	})
 	if err != nil {
 		log.Fatalf("Failed to create membership order: %v", err)
@@ -134,6 +132,7 @@ package wordgate
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -151,6 +150,8 @@ type Client struct {
 	BaseURL string
 	// HTTPClient is the HTTP client used for requests
 	HTTPClient *http.Client
+	// TierCache caches membership tiers resolved by tier code (nil disables caching)
+	TierCache *MembershipTierCache
 }
 
 // APIResponse represents a standard API response wrapper
@@ -160,18 +161,65 @@ type APIResponse struct {
 	Msg  string      `json:"msg,omitempty"`
 }
 
-// APIError represents an API error response
+// APIError represents an error reported by the API in the response envelope
 type APIError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	// StatusCode is the HTTP status code of the response carrying the error
+	StatusCode int `json:"-"`
 }
 
-
 // Error implements the error interface for APIError
 func (e APIError) Error() string {
 	return fmt.Sprintf("API error (code %d): %s", e.Code, e.Message)
 }
 
+// Is reports whether the target is an APIError with the same code,
+// so that errors.Is(err, wordgate.ErrOrderAlreadyCancelled) matches API responses
+func (e APIError) Is(target error) bool {
+	t, ok := target.(APIError)
+	return ok && t.Code == e.Code
+}
+
+// HTTPError represents a non-200 response whose body is not an API envelope,
+// such as an error page returned by a proxy or load balancer
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Body is the raw response body
+	Body string
+}
+
+// Error implements the error interface for HTTPError
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// Business errors returned by the WordGate API, compare with errors.Is
+//
+// The API reports these conditions with HTTP 409 and a business code in the envelope's code
+// field. Each code identifies one condition across all endpoints, so the sentinels match
+// regardless of which endpoint returned them; the endpoints returning each code are listed below.
+var (
+	// ErrOrderAlreadyCancelled (40901) is returned by CancelOrder for a cancelled order
+	ErrOrderAlreadyCancelled = APIError{Code: 40901, Message: "order already cancelled"}
+	// ErrOrderAlreadyPaid (40902) is returned by CancelOrder and MarkOrderAsPaid for a paid order
+	ErrOrderAlreadyPaid = APIError{Code: 40902, Message: "order already paid"}
+	// ErrOrderNotPaid (40903) is returned by RefundOrder and ShipOrder for an unpaid order
+	ErrOrderNotPaid = APIError{Code: 40903, Message: "order not paid"}
+	// ErrRefundExceedsPaidAmount (40904) is returned by RefundOrder when the amount exceeds the refundable amount
+	ErrRefundExceedsPaidAmount = APIError{Code: 40904, Message: "refund amount exceeds refundable amount"}
+	// ErrOrderNotShippable (40906) is returned by ShipOrder for an order without items that require shipping
+	ErrOrderNotShippable = APIError{Code: 40906, Message: "order does not require shipping"}
+	// ErrOrderAlreadyShipped (40907) is returned by ShipOrder for a shipped order
+	ErrOrderAlreadyShipped = APIError{Code: 40907, Message: "order already shipped"}
+	// ErrOrderNotShipped (40908) is returned by MarkOrderDelivered for an order that was not shipped
+	ErrOrderNotShipped = APIError{Code: 40908, Message: "order not shipped"}
+	// ErrVersionConflict (40909) is returned by UpdateProduct and UpdateMembershipTier when the
+	// version no longer matches, see VersionConflictError
+	ErrVersionConflict = APIError{Code: 40909, Message: "version conflict"}
+)
+
 // NewClient creates a new WordGate API client
 //
 // appCode: The application code for authentication
@@ -185,15 +233,42 @@ func NewClient(appCode, appSecret, baseURL string) *Client {
 		HTTPClient: &http.Client{
 			Timeout: time.Second * 30,
 		},
+		TierCache: NewMembershipTierCache(DefaultMembershipTierCacheTTL),
 	}
 }
 
-// request performs an HTTP request to the API
+// requestContext performs an HTTP request to the API bound to a context
 //
+// ctx: Context controlling cancellation of the request
 // method: HTTP method (GET, POST, etc.)
 // path: API endpoint path
 // body: Request body (will be JSON encoded if not nil)
-func (c *Client) request(method, path string, body interface{}) (*http.Response, error) {
+func (c *Client) requestContext(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	req, err := newJSONRequest(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set authentication headers
+	req.Header.Set("X-App-Code", c.AppCode)
+	req.Header.Set("X-App-Secret", c.AppSecret)
+
+	// Send request
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+
+	return resp, nil
+}
+
+// newJSONRequest creates an HTTP request with a JSON encoded body
+//
+// ctx: Context controlling cancellation of the request
+// method: HTTP method (GET, POST, etc.)
+// url: Full request URL
+// body: Request body (will be JSON encoded if not nil)
+func newJSONRequest(ctx context.Context, method, url string, body interface{}) (*http.Request, error) {
 	var reqBody io.Reader
 
 	// Encode request body as JSON if provided
@@ -205,11 +280,8 @@ func (c *Client) request(method, path string, body interface{}) (*http.Response,
 		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	// Build full URL
-	url := fmt.Sprintf("%s%s", c.BaseURL, path)
-
 	// Create HTTP request
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
@@ -218,16 +290,8 @@ func (c *Client) request(method, path string, body interface{}) (*http.Response,
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	req.Header.Set("X-App-Code", c.AppCode)
-	req.Header.Set("X-App-Secret", c.AppSecret)
 
-	// Send request
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send HTTP request: %w", err)
-	}
-
-	return resp, nil
+	return req, nil
 }
 
 // requestJSON performs an HTTP request and unmarshals the JSON response
@@ -237,10 +301,29 @@ func (c *Client) request(method, path string, body interface{}) (*http.Response,
 // body: Request body (will be JSON encoded if not nil)
 // result: Pointer to the result structure
 func (c *Client) requestJSON(method, path string, body interface{}, result interface{}) error {
-	resp, err := c.request(method, path, body)
+	return c.requestJSONContext(context.Background(), method, path, body, result)
+}
+
+// requestJSONContext performs an HTTP request bound to a context and unmarshals the JSON response
+//
+// ctx: Context controlling cancellation of the request
+// method: HTTP method (GET, POST, etc.)
+// path: API endpoint path
+// body: Request body (will be JSON encoded if not nil)
+// result: Pointer to the result structure
+func (c *Client) requestJSONContext(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	resp, err := c.requestContext(ctx, method, path, body)
 	if err != nil {
 		return err
 	}
+	return decodeResponse(resp, result)
+}
+
+// decodeResponse reads an API response and unmarshals its data field
+//
+// resp: The HTTP response, its body is closed
+// result: Pointer to the result structure
+func decodeResponse(resp *http.Response, result interface{}) error {
 	defer resp.Body.Close()
 
 	// Read response body
@@ -251,13 +334,28 @@ func (c *Client) requestJSON(method, path string, body interface{}, result inter
 
 	// Check HTTP status code
 	if resp.StatusCode != http.StatusOK {
-		// Try to parse as APIError
-		var apiErr APIError
-		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Message != "" {
-			return apiErr
+		// Errors use the same {code, data, msg} envelope as successful responses;
+		// some endpoints send {code, message} instead
+		var errResp struct {
+			Code    int    `json:"code"`
+			Msg     string `json:"msg"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(respBody, &errResp); err == nil {
+			message := errResp.Msg
+			if message == "" {
+				message = errResp.Message
+			}
+			if errResp.Code != 0 || message != "" {
+				code := errResp.Code
+				if code == 0 {
+					code = resp.StatusCode
+				}
+				return APIError{Code: code, Message: message, StatusCode: resp.StatusCode}
+			}
 		}
 		// Fallback to HTTP error
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+		return &HTTPError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	// Parse API response wrapper
@@ -269,8 +367,9 @@ func (c *Client) requestJSON(method, path string, body interface{}, result inter
 	// Check API response code
 	if apiResp.Code != 0 {
 		return APIError{
-			Code:    apiResp.Code,
-			Message: apiResp.Msg,
+			Code:       apiResp.Code,
+			Message:    apiResp.Msg,
+			StatusCode: resp.StatusCode,
 		}
 	}
 
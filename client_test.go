@@ -0,0 +1,107 @@
+package wordgate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDecodeResponseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		check  func(t *testing.T, err error)
+	}{
+		{
+			name:   "business error in non-200 envelope",
+			status: http.StatusConflict,
+			body:   `{"code":40901,"msg":"order already cancelled"}`,
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrOrderAlreadyCancelled) {
+					t.Fatalf("error = %v, want ErrOrderAlreadyCancelled", err)
+				}
+				var apiErr APIError
+				if !errors.As(err, &apiErr) || apiErr.Message != "order already cancelled" || apiErr.StatusCode != http.StatusConflict {
+					t.Fatalf("APIError = %+v", apiErr)
+				}
+			},
+		},
+		{
+			name:   "envelope without code uses HTTP status",
+			status: http.StatusUnauthorized,
+			body:   `{"msg":"invalid app credentials"}`,
+			check: func(t *testing.T, err error) {
+				var apiErr APIError
+				if !errors.As(err, &apiErr) || apiErr.Code != http.StatusUnauthorized {
+					t.Fatalf("error = %v, want APIError with code 401", err)
+				}
+			},
+		},
+		{
+			name:   "code and message body",
+			status: http.StatusNotFound,
+			body:   `{"code":404,"message":"order not found"}`,
+			check: func(t *testing.T, err error) {
+				var apiErr APIError
+				if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound || apiErr.Message != "order not found" {
+					t.Fatalf("error = %v, want APIError 404 with message", err)
+				}
+			},
+		},
+		{
+			name:   "message without code uses HTTP status",
+			status: http.StatusForbidden,
+			body:   `{"message":"app disabled"}`,
+			check: func(t *testing.T, err error) {
+				var apiErr APIError
+				if !errors.As(err, &apiErr) || apiErr.Code != http.StatusForbidden || apiErr.Message != "app disabled" {
+					t.Fatalf("error = %v, want APIError 403 with message", err)
+				}
+			},
+		},
+		{
+			name:   "business error in 200 envelope",
+			status: http.StatusOK,
+			body:   `{"code":40909,"msg":"version conflict"}`,
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrVersionConflict) {
+					t.Fatalf("error = %v, want ErrVersionConflict", err)
+				}
+			},
+		},
+		{
+			name:   "non-envelope body",
+			status: http.StatusBadGateway,
+			body:   `<html>bad gateway</html>`,
+			check: func(t *testing.T, err error) {
+				var httpErr *HTTPError
+				if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+					t.Fatalf("error = %v, want HTTPError 502", err)
+				}
+				var apiErr APIError
+				if errors.As(err, &apiErr) {
+					t.Fatalf("error = %v, should not be an APIError", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient("app", "secret", server.URL)
+			err := client.requestJSON("GET", "/app/orders/WG1", nil, nil)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			tt.check(t, err)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Command wordgate-webhook-replay re-sends captured WordGate webhook requests to an endpoint.
+
+It reads a JSON Lines capture file written by wordgate.WebhookRecorder, applies the given
+filters, verifies each record against the secret without checking the timestamp age, then
+re-signs the original body with the current time and POSTs it to the target URL.
+
+Usage:
+
+	wordgate-webhook-replay -file webhooks.jsonl -url http://localhost:8080/webhook -secret $WEBHOOK_SECRET \
+		-type order.paid -since 2024-01-01T00:00:00Z -order WG202401010001
+*/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+func main() {
+	file := flag.String("file", "", "capture file in JSON Lines format (required)")
+	target := flag.String("url", "", "webhook endpoint to deliver to (required)")
+	secret := flag.String("secret", os.Getenv("WORDGATE_WEBHOOK_SECRET"), "webhook signing secret (defaults to $WORDGATE_WEBHOOK_SECRET)")
+	types := flag.String("type", "", "comma separated event types to replay")
+	since := flag.String("since", "", "only replay records received at or after this RFC3339 time")
+	until := flag.String("until", "", "only replay records received before this RFC3339 time")
+	orderNo := flag.String("order", "", "only replay events for this order number")
+	dryRun := flag.Bool("dry-run", false, "print matching records without sending them")
+	flag.Parse()
+
+	if *file == "" || *target == "" || *secret == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	filter := &wordgate.WebhookReplayFilter{OrderNo: *orderNo}
+	if *types != "" {
+		for _, eventType := range strings.Split(*types, ",") {
+			filter.EventTypes = append(filter.EventTypes, wordgate.WebhookEventType(strings.TrimSpace(eventType)))
+		}
+	}
+	var err error
+	if filter.Since, err = parseTime(*since); err != nil {
+		log.Fatalf("Invalid -since: %v", err)
+	}
+	if filter.Until, err = parseTime(*until); err != nil {
+		log.Fatalf("Invalid -until: %v", err)
+	}
+
+	input, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("Failed to open capture file: %v", err)
+	}
+	defer input.Close()
+
+	client := &http.Client{Timeout: time.Second * 30}
+	var sent, failed int
+
+	err = wordgate.ReadWebhookRecords(input, func(record *wordgate.WebhookRecord) error {
+		event, err := record.Event()
+		if err != nil {
+			log.Printf("Skipping unreadable record: %v", err)
+			failed++
+			return nil
+		}
+		if !filter.Match(record, event) {
+			return nil
+		}
+
+		receivedAt := record.ReceivedAt.Format(time.RFC3339)
+		if err := record.Verify(*secret); err != nil {
+			log.Printf("Skipping %s event received at %s: %v", event.EventType, receivedAt, err)
+			failed++
+			return nil
+		}
+
+		if *dryRun {
+			fmt.Printf("%s %s\n", receivedAt, event.EventType)
+			sent++
+			return nil
+		}
+
+		if err := deliver(client, *target, *secret, []byte(record.Body)); err != nil {
+			log.Printf("Failed to deliver %s event received at %s: %v", event.EventType, receivedAt, err)
+			failed++
+			return nil
+		}
+		sent++
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to read capture file: %v", err)
+	}
+
+	fmt.Printf("Replayed %d events, %d failed\n", sent, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// deliver re-signs the body with the current time and POSTs it to the target URL
+func deliver(client *http.Client, target, secret string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(wordgate.WebhookSignatureHeader, wordgate.GenerateSignatureHeader(time.Now().Unix(), body, secret))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected HTTP status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// parseTime parses an optional RFC3339 time flag
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,351 @@
+package wordgate
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// CouponType represents how a coupon discount is calculated
+type CouponType string
+
+const (
+	// CouponTypePercentage discounts a percentage of the eligible amount
+	CouponTypePercentage CouponType = "percentage"
+	// CouponTypeFixedAmount discounts a fixed amount in cents
+	CouponTypeFixedAmount CouponType = "fixed_amount"
+)
+
+// CouponStatus represents the status of a coupon
+type CouponStatus string
+
+const (
+	// CouponStatusActive indicates the coupon can be redeemed
+	CouponStatusActive CouponStatus = "active"
+	// CouponStatusInactive indicates the coupon cannot be redeemed
+	CouponStatusInactive CouponStatus = "inactive"
+)
+
+// Coupon represents a coupon in the WordGate system
+type Coupon struct {
+	// ID is the unique identifier of the coupon
+	ID uint64 `json:"id"`
+	// AppID is the application ID this coupon belongs to
+	AppID uint64 `json:"app_id"`
+	// Code is the unique coupon code entered by users
+	Code string `json:"code"`
+	// Name is the coupon name
+	Name string `json:"name"`
+	// Type is the discount type (percentage/fixed_amount)
+	Type CouponType `json:"type"`
+	// Value is the discount percentage (1-100) or the fixed discount in cents
+	Value int64 `json:"value"`
+	// MinOrderAmount is the minimum eligible amount in cents required to redeem the coupon (0 for none)
+	MinOrderAmount int64 `json:"min_order_amount"`
+	// MaxDiscountAmount caps the discount in cents (0 for no cap)
+	MaxDiscountAmount int64 `json:"max_discount_amount"`
+	// ProductCodes restricts the coupon to these products (empty with empty TierCodes applies to everything)
+	ProductCodes []string `json:"product_codes"`
+	// TierCodes restricts the coupon to these membership tiers (empty with empty ProductCodes applies to everything)
+	TierCodes []string `json:"tier_codes"`
+	// UsageLimit is the total number of redemptions allowed (0 for unlimited)
+	UsageLimit int `json:"usage_limit"`
+	// PerUserLimit is the number of redemptions allowed per user (0 for unlimited)
+	PerUserLimit int `json:"per_user_limit"`
+	// UsedCount is the number of times the coupon has been redeemed
+	UsedCount int `json:"used_count"`
+	// StartsAt is the beginning of the validity window (nil for immediately)
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	// ExpiresAt is the end of the validity window (nil for never)
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Status is the coupon status (active/inactive)
+	Status CouponStatus `json:"status"`
+	// Version is the version number for optimistic locking
+	Version int `json:"version"`
+	// CreatedAt is the creation timestamp
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is the last update timestamp
+	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt is the deletion timestamp (nil if not deleted)
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// CreateCouponRequest represents a request to create a coupon
+type CreateCouponRequest struct {
+	// Code is the unique coupon code
+	Code string `json:"code" binding:"required,max=50"`
+	// Name is the coupon name
+	Name string `json:"name" binding:"required,max=100"`
+	// Type is the discount type (percentage/fixed_amount)
+	Type CouponType `json:"type" binding:"required"`
+	// Value is the discount percentage (1-100) or the fixed discount in cents
+	Value int64 `json:"value" binding:"required,min=1"`
+	// MinOrderAmount is the minimum eligible amount in cents (optional)
+	MinOrderAmount int64 `json:"min_order_amount,omitempty"`
+	// MaxDiscountAmount caps the discount in cents (optional)
+	MaxDiscountAmount int64 `json:"max_discount_amount,omitempty"`
+	// ProductCodes restricts the coupon to these products (optional)
+	ProductCodes []string `json:"product_codes,omitempty"`
+	// TierCodes restricts the coupon to these membership tiers (optional)
+	TierCodes []string `json:"tier_codes,omitempty"`
+	// UsageLimit is the total number of redemptions allowed (optional)
+	UsageLimit int `json:"usage_limit,omitempty"`
+	// PerUserLimit is the number of redemptions allowed per user (optional)
+	PerUserLimit int `json:"per_user_limit,omitempty"`
+	// StartsAt is the beginning of the validity window (optional)
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	// ExpiresAt is the end of the validity window (optional)
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// UpdateCouponRequest represents a request to update a coupon
+type UpdateCouponRequest struct {
+	// Name is the coupon name
+	Name string `json:"name" binding:"required,max=100"`
+	// Type is the discount type (percentage/fixed_amount)
+	Type CouponType `json:"type" binding:"required"`
+	// Value is the discount percentage (1-100) or the fixed discount in cents
+	Value int64 `json:"value" binding:"required,min=1"`
+	// MinOrderAmount is the minimum eligible amount in cents
+	MinOrderAmount int64 `json:"min_order_amount"`
+	// MaxDiscountAmount caps the discount in cents
+	MaxDiscountAmount int64 `json:"max_discount_amount"`
+	// ProductCodes restricts the coupon to these products
+	ProductCodes []string `json:"product_codes"`
+	// TierCodes restricts the coupon to these membership tiers
+	TierCodes []string `json:"tier_codes"`
+	// UsageLimit is the total number of redemptions allowed
+	UsageLimit int `json:"usage_limit"`
+	// PerUserLimit is the number of redemptions allowed per user
+	PerUserLimit int `json:"per_user_limit"`
+	// StartsAt is the beginning of the validity window
+	StartsAt *time.Time `json:"starts_at"`
+	// ExpiresAt is the end of the validity window
+	ExpiresAt *time.Time `json:"expires_at"`
+	// Status is the coupon status (active/inactive)
+	Status CouponStatus `json:"status" binding:"required"`
+}
+
+// ListCouponsRequest represents a request to list coupons
+type ListCouponsRequest struct {
+	// Status filters coupons by status (optional)
+	Status CouponStatus `json:"status,omitempty"`
+	// ShowDeleted indicates whether to show deleted coupons
+	ShowDeleted bool `json:"show_deleted,omitempty"`
+	// Page is the page number (starting from 1)
+	Page int `json:"page,omitempty"`
+	// Limit is the number of items per page
+	Limit int `json:"limit,omitempty"`
+}
+
+// CouponListResponse represents a paginated list of coupons
+type CouponListResponse struct {
+	// Data is the list of coupons
+	Data []Coupon `json:"data"`
+	// Pagination contains pagination information
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// ValidateCouponRequest represents a request to check whether a coupon can be redeemed
+type ValidateCouponRequest struct {
+	// Code is the coupon code to validate
+	Code string `json:"code" binding:"required"`
+	// UserUID is the user redeeming the coupon, used to check per-user limits (optional)
+	UserUID string `json:"user_uid,omitempty"`
+	// ProductCodes are the products being ordered (optional)
+	ProductCodes []string `json:"product_codes,omitempty"`
+	// TierCode is the membership tier being ordered (optional)
+	TierCode string `json:"tier_code,omitempty"`
+	// Amount is the order amount in cents before discount
+	Amount int64 `json:"amount"`
+}
+
+// ValidateCouponResponse represents the result of a coupon validation
+type ValidateCouponResponse struct {
+	// Valid indicates whether the coupon can be redeemed
+	Valid bool `json:"valid"`
+	// Reason explains why the coupon cannot be redeemed (empty if valid)
+	Reason string `json:"reason,omitempty"`
+	// DiscountAmount is the discount the coupon would grant in cents
+	DiscountAmount int64 `json:"discount_amount"`
+	// Coupon is the coupon details (nil if the code is unknown)
+	Coupon *Coupon `json:"coupon,omitempty"`
+}
+
+// AppliesToProduct reports whether the coupon can discount the product
+func (c *Coupon) AppliesToProduct(code string) bool {
+	if len(c.ProductCodes) == 0 && len(c.TierCodes) == 0 {
+		return true
+	}
+	return slices.Contains(c.ProductCodes, code)
+}
+
+// AppliesToTier reports whether the coupon can discount the membership tier
+func (c *Coupon) AppliesToTier(code string) bool {
+	if len(c.ProductCodes) == 0 && len(c.TierCodes) == 0 {
+		return true
+	}
+	return slices.Contains(c.TierCodes, code)
+}
+
+// IsRedeemable reports whether the coupon is active, not deleted, within its validity
+// window and below its usage limit at the given time
+func (c *Coupon) IsRedeemable(now time.Time) bool {
+	if c.Status != CouponStatusActive || c.DeletedAt != nil {
+		return false
+	}
+	if c.StartsAt != nil && now.Before(*c.StartsAt) {
+		return false
+	}
+	if c.ExpiresAt != nil && !now.Before(*c.ExpiresAt) {
+		return false
+	}
+	return c.UsageLimit == 0 || c.UsedCount < c.UsageLimit
+}
+
+// Discount calculates the discount for an eligible amount
+//
+// amount: The eligible amount in cents
+// Returns the discount in cents, 0 if the amount is below MinOrderAmount
+func (c *Coupon) Discount(amount int64) int64 {
+	if amount <= 0 || amount < c.MinOrderAmount {
+		return 0
+	}
+
+	var discount int64
+	switch c.Type {
+	case CouponTypePercentage:
+		discount = amount * c.Value / 100
+	case CouponTypeFixedAmount:
+		discount = c.Value
+	}
+	if c.MaxDiscountAmount > 0 && discount > c.MaxDiscountAmount {
+		discount = c.MaxDiscountAmount
+	}
+	if discount > amount {
+		discount = amount
+	}
+	return discount
+}
+
+// CreateCoupon creates a new coupon
+//
+// request: The coupon creation request containing coupon details
+// Returns the created coupon information and any error
+func (c *Client) CreateCoupon(request *CreateCouponRequest) (*Coupon, error) {
+	var result Coupon
+	err := c.requestJSON("POST", "/app/coupons", request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create coupon: %w", err)
+	}
+	return &result, nil
+}
+
+// GetCoupon retrieves coupon details by coupon code
+//
+// code: The coupon code to retrieve
+// Returns the coupon details and any error
+func (c *Client) GetCoupon(code string) (*Coupon, error) {
+	var result Coupon
+	path := fmt.Sprintf("/app/coupons/%s", url.PathEscape(code))
+	err := c.requestJSON("GET", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+	return &result, nil
+}
+
+// UpdateCoupon updates an existing coupon
+//
+// code: The coupon code to update
+// request: The coupon update request containing new coupon details
+// Returns the updated coupon information and any error
+func (c *Client) UpdateCoupon(code string, request *UpdateCouponRequest) (*Coupon, error) {
+	var result Coupon
+	path := fmt.Sprintf("/app/coupons/%s", url.PathEscape(code))
+	err := c.requestJSON("PUT", path, request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update coupon: %w", err)
+	}
+	return &result, nil
+}
+
+// DeleteCoupon deletes a coupon by code
+//
+// code: The coupon code to delete
+// Returns any error encountered during deletion
+func (c *Client) DeleteCoupon(code string) error {
+	var result map[string]interface{}
+	path := fmt.Sprintf("/app/coupons/%s", url.PathEscape(code))
+	err := c.requestJSON("DELETE", path, nil, &result)
+	if err != nil {
+		return fmt.Errorf("failed to delete coupon: %w", err)
+	}
+	return nil
+}
+
+// RestoreCoupon restores a previously deleted coupon
+//
+// code: The coupon code to restore
+// Returns the restored coupon information and any error
+func (c *Client) RestoreCoupon(code string) (*Coupon, error) {
+	var result Coupon
+	path := fmt.Sprintf("/app/coupons/%s/restore", url.PathEscape(code))
+	err := c.requestJSON("POST", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore coupon: %w", err)
+	}
+	return &result, nil
+}
+
+// ListCoupons retrieves a paginated list of coupons
+//
+// request: The list request containing filter and pagination parameters
+// Returns the coupon list with pagination information and any error
+func (c *Client) ListCoupons(request *ListCouponsRequest) (*CouponListResponse, error) {
+	// Build query parameters
+	params := url.Values{}
+
+	if request != nil {
+		if request.Status != "" {
+			params.Set("status", string(request.Status))
+		}
+		if request.ShowDeleted {
+			params.Set("show_deleted", "true")
+		}
+		if request.Page > 0 {
+			params.Set("page", strconv.Itoa(request.Page))
+		}
+		if request.Limit > 0 {
+			params.Set("limit", strconv.Itoa(request.Limit))
+		}
+	}
+
+	// Build path with query parameters
+	path := "/app/coupons"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var result CouponListResponse
+	err := c.requestJSON("GET", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coupons: %w", err)
+	}
+	return &result, nil
+}
+
+// ValidateCoupon checks whether a coupon can be redeemed for an order without redeeming it
+//
+// request: The validation request containing the coupon code and order details
+// Returns the validation result and any error; an unusable coupon is reported with Valid false, not an error
+func (c *Client) ValidateCoupon(request *ValidateCouponRequest) (*ValidateCouponResponse, error) {
+	var result ValidateCouponResponse
+	err := c.requestJSON("POST", "/app/coupons/validate", request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate coupon: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,350 @@
+package wordgate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportFormat represents the output format of an order export
+type ExportFormat string
+
+const (
+	// ExportFormatCSV writes one CSV record per order with a header row
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatJSONL writes one JSON object per line
+	ExportFormatJSONL ExportFormat = "jsonl"
+)
+
+// ExportRow is the data available to export columns for an order
+type ExportRow struct {
+	// Order is the order as returned by ListAppOrders
+	Order *OrderListItem
+	// Detail is the order details, only fetched when a column needs them
+	Detail *OrderDetailResponse
+}
+
+// ExportColumn describes one exported field
+type ExportColumn struct {
+	// Name is the CSV header and JSON key
+	Name string
+	// Value extracts the field from a row
+	Value func(row *ExportRow) any
+	// NeedsDetail indicates the column reads Detail, which costs one GetAppOrder call per order
+	NeedsDetail bool
+}
+
+// exportColumns are the built-in columns by name
+var exportColumns = []ExportColumn{
+	{Name: "order_no", Value: func(r *ExportRow) any { return r.Order.OrderNo }},
+	{Name: "user_id", Value: func(r *ExportRow) any { return r.Order.UserID }},
+	{Name: "created_at", Value: func(r *ExportRow) any { return r.Order.CreatedAt.Format(time.RFC3339) }},
+	{Name: "is_paid", Value: func(r *ExportRow) any { return r.Order.IsPaid }},
+	{Name: "paid_at", Value: func(r *ExportRow) any {
+		if r.Order.PaidAt == nil {
+			return ""
+		}
+		return r.Order.PaidAt.Format(time.RFC3339)
+	}},
+	{Name: "amount", Value: func(r *ExportRow) any { return r.Order.Amount }},
+	{Name: "currency", Value: func(r *ExportRow) any { return r.Order.Currency }},
+	{Name: "items_count", Value: func(r *ExportRow) any { return r.Order.ItemsCount }},
+	{Name: "items_summary", Value: func(r *ExportRow) any { return r.Order.ItemsSummary }},
+	{Name: "payment_provider", Value: func(r *ExportRow) any {
+		return joinIntents(r.Order.PaymentIntents, func(p PaymentIntentInfo) string { return p.Provider })
+	}},
+	{Name: "payment_intent_id", Value: func(r *ExportRow) any {
+		return joinIntents(r.Order.PaymentIntents, func(p PaymentIntentInfo) string { return p.IntentID })
+	}},
+	{Name: "payment_status", Value: func(r *ExportRow) any {
+		return joinIntents(r.Order.PaymentIntents, func(p PaymentIntentInfo) string { return p.Status })
+	}},
+	{Name: "external_ref", Value: func(r *ExportRow) any { return r.Order.ExternalRef }},
+	{Name: "fulfillment_status", Value: func(r *ExportRow) any { return string(r.Order.FulfillmentStatus) }},
+	{Name: "coupon_code", NeedsDetail: true, Value: func(r *ExportRow) any { return r.Detail.CouponCode }},
+	{Name: "discount_amount", NeedsDetail: true, Value: func(r *ExportRow) any { return r.Detail.DiscountAmount }},
+	{Name: "items", NeedsDetail: true, Value: func(r *ExportRow) any {
+		items := make([]string, 0, len(r.Detail.Items))
+		for _, item := range r.Detail.Items {
+			items = append(items, fmt.Sprintf("%s x%d @ %d", item.ItemName, item.Quantity, item.UnitPrice))
+		}
+		return strings.Join(items, "; ")
+	}},
+}
+
+// DefaultExportColumns returns the columns exported when none are configured, which
+// need no per-order detail requests
+func DefaultExportColumns() []ExportColumn {
+	columns, _ := ExportColumns("order_no", "user_id", "created_at", "is_paid", "paid_at",
+		"amount", "currency", "items_count", "items_summary", "payment_provider", "payment_intent_id", "payment_status")
+	return columns
+}
+
+// ExportColumns looks up built-in columns by name
+//
+// Available columns: order_no, user_id, created_at, is_paid, paid_at, amount, currency,
+// items_count, items_summary, payment_provider, payment_intent_id, payment_status, external_ref,
+// fulfillment_status, and coupon_code, discount_amount and items, which require order details.
+//
+// names: The column names in output order
+// Returns the columns, or an error naming an unknown column
+func ExportColumns(names ...string) ([]ExportColumn, error) {
+	columns := make([]ExportColumn, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, column := range exportColumns {
+			if column.Name == name {
+				columns = append(columns, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown export column: %s", name)
+		}
+	}
+	return columns, nil
+}
+
+// OrderExportResult summarizes an export run
+type OrderExportResult struct {
+	// Orders is the number of orders written
+	Orders int
+	// LastPage is the last page fully written; set Query.Page to LastPage+1 to resume
+	LastPage int
+}
+
+// OrderExporter streams all orders matching a query to CSV or JSON Lines
+//
+// Usage example:
+//
+//	exporter := wordgate.NewOrderExporter(client, &wordgate.ListOrdersQuery{
+//		Status:  "paid",
+//		StartAt: "2024-01-01",
+//		EndAt:   "2024-01-31",
+//	}, wordgate.ExportFormatCSV)
+//	exporter.OnPage = func(page int) error { return saveCheckpoint(page) }
+//	result, err := exporter.Export(ctx, file)
+type OrderExporter struct {
+	// Client is the WordGate API client
+	Client *Client
+	// Query filters the exported orders; Page > 1 resumes an earlier export and skips the CSV header
+	Query ListOrdersQuery
+	// Format is the output format
+	Format ExportFormat
+	// Columns are the exported fields in order (defaults to DefaultExportColumns)
+	Columns []ExportColumn
+	// OnPage is called after each page has been written to the destination, for checkpointing (optional)
+	OnPage func(page int) error
+}
+
+// NewOrderExporter creates an exporter with the default columns
+//
+// client: The WordGate API client
+// query: The query filtering the exported orders (optional)
+// format: The output format
+func NewOrderExporter(client *Client, query *ListOrdersQuery, format ExportFormat) *OrderExporter {
+	e := &OrderExporter{
+		Client:  client,
+		Format:  format,
+		Columns: DefaultExportColumns(),
+	}
+	if query != nil {
+		e.Query = *query
+	}
+	return e
+}
+
+// Export writes all matching orders to w
+//
+// Each page is buffered and written to w in one piece together with its checkpoint, so an
+// error in the middle of a page leaves w ending at the last committed page.
+//
+// ctx: Context for cancellation between orders
+// w: The destination; when resuming, open it for appending
+// Returns the export summary, which is valid up to LastPage even when an error is returned
+func (e *OrderExporter) Export(ctx context.Context, w io.Writer) (*OrderExportResult, error) {
+	columns := e.Columns
+	if len(columns) == 0 {
+		columns = DefaultExportColumns()
+	}
+	needsDetail := false
+	for _, column := range columns {
+		needsDetail = needsDetail || column.NeedsDetail
+	}
+
+	var buf bytes.Buffer
+	writer, err := newExportWriter(e.Format, &buf, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &OrderExportResult{}
+	startPage := e.Query.Page
+	if startPage < 1 {
+		startPage = 1
+	}
+	result.LastPage = startPage - 1
+	if startPage == 1 {
+		if err := writer.header(); err != nil {
+			return result, fmt.Errorf("failed to write export header: %w", err)
+		}
+	}
+
+	// output writes the buffered rows to w
+	output := func() error {
+		if err := writer.flush(); err != nil {
+			return fmt.Errorf("failed to flush export: %w", err)
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		buf.Reset()
+		return nil
+	}
+
+	// commit writes the buffered page and records it as fully written
+	pending := 0
+	commit := func(page int) error {
+		if err := output(); err != nil {
+			return err
+		}
+		result.Orders += pending
+		pending = 0
+		result.LastPage = page
+		if e.OnPage != nil {
+			return e.OnPage(page)
+		}
+		return nil
+	}
+
+	iterator := e.Client.IterateAppOrders(&e.Query)
+	page := 0
+	for iterator.Next() {
+		if page != 0 && iterator.Page() != page {
+			if err := commit(page); err != nil {
+				return result, err
+			}
+		}
+		page = iterator.Page()
+
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		row := &ExportRow{Order: iterator.Order()}
+		if needsDetail {
+			row.Detail, err = e.Client.GetAppOrderContext(ctx, row.Order.OrderNo)
+			if err != nil {
+				return result, err
+			}
+		}
+		values := make([]any, len(columns))
+		for i, column := range columns {
+			values[i] = column.Value(row)
+		}
+		if err := writer.write(values); err != nil {
+			return result, fmt.Errorf("failed to write order %s: %w", row.Order.OrderNo, err)
+		}
+		pending++
+	}
+	if err := iterator.Err(); err != nil {
+		return result, err
+	}
+	if page != 0 {
+		return result, commit(page)
+	}
+	// No orders: write the header alone
+	return result, output()
+}
+
+// exportWriter writes rows in one export format
+type exportWriter struct {
+	header func() error
+	write  func(values []any) error
+	flush  func() error
+}
+
+// newExportWriter creates the writer for a format
+func newExportWriter(format ExportFormat, w io.Writer, columns []ExportColumn) (*exportWriter, error) {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+
+	switch format {
+	case ExportFormatCSV:
+		cw := csv.NewWriter(w)
+		return &exportWriter{
+			header: func() error { return cw.Write(names) },
+			write: func(values []any) error {
+				record := make([]string, len(values))
+				for i, value := range values {
+					record[i] = formatExportValue(value)
+				}
+				return cw.Write(record)
+			},
+			flush: func() error {
+				cw.Flush()
+				return cw.Error()
+			},
+		}, nil
+	case ExportFormatJSONL:
+		bw := bufio.NewWriter(w)
+		return &exportWriter{
+			header: func() error { return nil },
+			write: func(values []any) error {
+				// Build the object by hand to keep keys in column order
+				bw.WriteByte('{')
+				for i, value := range values {
+					if i > 0 {
+						bw.WriteByte(',')
+					}
+					key, _ := json.Marshal(names[i])
+					data, err := json.Marshal(value)
+					if err != nil {
+						return err
+					}
+					bw.Write(key)
+					bw.WriteByte(':')
+					bw.Write(data)
+				}
+				_, err := bw.WriteString("}\n")
+				return err
+			},
+			flush: bw.Flush,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// formatExportValue formats a column value as a CSV field
+func formatExportValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// joinIntents joins a field of all payment intents with ";"
+func joinIntents(intents []PaymentIntentInfo, field func(PaymentIntentInfo) string) string {
+	values := make([]string, 0, len(intents))
+	for _, intent := range intents {
+		values = append(values, field(intent))
+	}
+	return strings.Join(values, ";")
+}
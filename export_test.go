@@ -0,0 +1,117 @@
+package wordgate
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOrderExporterResumesFromLastCommittedPage(t *testing.T) {
+	orders := make([]OrderListItem, 5)
+	for i := range orders {
+		orders[i] = OrderListItem{OrderNo: fmt.Sprintf("WG%d", i+1), Amount: int64(100 * (i + 1)), IsPaid: true}
+	}
+	var failDetail atomic.Bool
+	failDetail.Store(true)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/app/orders" {
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			start := min((page-1)*limit, len(orders))
+			end := min(start+limit, len(orders))
+			json.NewEncoder(w).Encode(APIResponse{Data: ListResult{
+				Data:       orders[start:end],
+				Pagination: &Pagination{Page: page, Limit: limit, Total: int64(len(orders)), TotalPages: (len(orders) + limit - 1) / limit},
+			}})
+			return
+		}
+		orderNo := strings.TrimPrefix(r.URL.Path, "/app/orders/")
+		if orderNo == "WG4" && failDetail.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"code":503,"msg":"unavailable"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(APIResponse{Data: OrderDetailResponse{OrderNo: orderNo}})
+	}))
+	defer server.Close()
+
+	client := NewClient("app", "secret", server.URL)
+	columns, _ := ExportColumns("order_no", "amount")
+	columns = append(columns, ExportColumn{
+		Name:        "detail_order_no",
+		Value:       func(r *ExportRow) any { return r.Detail.OrderNo },
+		NeedsDetail: true,
+	})
+
+	var out bytes.Buffer
+	var checkpoints []int
+	exporter := NewOrderExporter(client, &ListOrdersQuery{Limit: 2}, ExportFormatCSV)
+	exporter.Columns = columns
+	exporter.OnPage = func(page int) error {
+		checkpoints = append(checkpoints, page)
+		return nil
+	}
+
+	result, err := exporter.Export(context.Background(), &out)
+	if err == nil {
+		t.Fatal("expected export to fail on WG4")
+	}
+	if result.LastPage != 1 || result.Orders != 2 {
+		t.Fatalf("result = %+v, want LastPage 1 and 2 orders", result)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 3 {
+		t.Fatalf("output has %d lines after failure, want header and 2 rows:\n%s", lines, out.String())
+	}
+
+	// Resume after the last committed page, appending to the same output
+	failDetail.Store(false)
+	exporter.Query.Page = result.LastPage + 1
+	result, err = exporter.Export(context.Background(), &out)
+	if err != nil {
+		t.Fatalf("resumed Export() error = %v", err)
+	}
+	if result.LastPage != 3 || result.Orders != 3 {
+		t.Fatalf("resumed result = %+v, want LastPage 3 and 3 orders", result)
+	}
+	if fmt.Sprint(checkpoints) != "[1 2 3]" {
+		t.Fatalf("checkpoints = %v, want [1 2 3]", checkpoints)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, record := range records {
+		got = append(got, record[0])
+	}
+	if want := "order_no,WG1,WG2,WG3,WG4,WG5"; strings.Join(got, ",") != want {
+		t.Fatalf("exported order numbers = %v, want %s", got, want)
+	}
+}
+
+func TestOrderExporterWritesHeaderWithoutOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(APIResponse{Data: ListResult{Data: []OrderListItem{}}})
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	exporter := NewOrderExporter(NewClient("app", "secret", server.URL), nil, ExportFormatCSV)
+	exporter.Columns, _ = ExportColumns("order_no", "amount")
+	if _, err := exporter.Export(context.Background(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "order_no,amount\n" {
+		t.Fatalf("output = %q, want header only", out.String())
+	}
+}
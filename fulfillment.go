@@ -0,0 +1,118 @@
+package wordgate
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// FulfillmentStatus represents the shipping status of an order
+type FulfillmentStatus string
+
+const (
+	// FulfillmentStatusNotRequired indicates the order has no items that require shipping
+	FulfillmentStatusNotRequired FulfillmentStatus = "not_required"
+	// FulfillmentStatusUnfulfilled indicates the order requires shipping and has not been shipped
+	FulfillmentStatusUnfulfilled FulfillmentStatus = "unfulfilled"
+	// FulfillmentStatusShipped indicates the order has been handed to the carrier
+	FulfillmentStatusShipped FulfillmentStatus = "shipped"
+	// FulfillmentStatusDelivered indicates the order has been delivered
+	FulfillmentStatusDelivered FulfillmentStatus = "delivered"
+)
+
+// Shipment represents the shipping information of an order
+type Shipment struct {
+	// Carrier is the shipping carrier (e.g., "SF Express")
+	Carrier string `json:"carrier"`
+	// TrackingNumber is the carrier's tracking number
+	TrackingNumber string `json:"tracking_number"`
+	// TrackingURL is the tracking page URL (optional)
+	TrackingURL string `json:"tracking_url,omitempty"`
+	// ShippedAt is the shipping timestamp
+	ShippedAt *time.Time `json:"shipped_at"`
+	// DeliveredAt is the delivery timestamp (nil if not delivered)
+	DeliveredAt *time.Time `json:"delivered_at"`
+}
+
+// ShipOrderRequest represents a request to mark an order as shipped
+type ShipOrderRequest struct {
+	// Carrier is the shipping carrier (required)
+	Carrier string `json:"carrier" binding:"required"`
+	// TrackingNumber is the carrier's tracking number (required)
+	TrackingNumber string `json:"tracking_number" binding:"required"`
+	// TrackingURL is the tracking page URL (optional)
+	TrackingURL string `json:"tracking_url,omitempty"`
+	// ShippedAt is the shipping time (optional, defaults to now)
+	ShippedAt *time.Time `json:"shipped_at,omitempty"`
+}
+
+// MarkDeliveredRequest represents a request to mark an order as delivered
+type MarkDeliveredRequest struct {
+	// DeliveredAt is the delivery time (optional, defaults to now)
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+}
+
+// ShipOrder marks a paid order that requires an address as shipped
+//
+// Returns ErrOrderNotPaid if the order is not paid, ErrOrderNotShippable if the order does not
+// require shipping and ErrOrderAlreadyShipped if it was shipped before; compare with errors.Is
+//
+// orderNo: The order number to ship
+// request: The shipment containing carrier and tracking number
+// Returns the updated order details and any error
+func (c *Client) ShipOrder(orderNo string, request *ShipOrderRequest) (*OrderDetailResponse, error) {
+	if request == nil || request.Carrier == "" {
+		return nil, fmt.Errorf("failed to ship order: carrier is required")
+	}
+	if request.TrackingNumber == "" {
+		return nil, fmt.Errorf("failed to ship order: tracking number is required")
+	}
+
+	var result OrderDetailResponse
+	path := fmt.Sprintf("/app/orders/%s/ship", url.PathEscape(orderNo))
+	err := c.requestJSON("POST", path, request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ship order: %w", err)
+	}
+	return &result, nil
+}
+
+// MarkOrderDelivered marks a shipped order as delivered
+//
+// Returns ErrOrderNotShipped if the order has not been shipped; compare with errors.Is
+//
+// orderNo: The order number to mark as delivered
+// request: The delivery request (optional)
+// Returns the updated order details and any error
+func (c *Client) MarkOrderDelivered(orderNo string, request *MarkDeliveredRequest) (*OrderDetailResponse, error) {
+	if request == nil {
+		request = &MarkDeliveredRequest{}
+	}
+
+	var result OrderDetailResponse
+	path := fmt.Sprintf("/app/orders/%s/deliver", url.PathEscape(orderNo))
+	err := c.requestJSON("POST", path, request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark order as delivered: %w", err)
+	}
+	return &result, nil
+}
+
+// ListPendingFulfillment retrieves paid orders that require an address and have not been shipped
+//
+// query: Additional filters and pagination (optional); the status and fulfillment filters are overridden
+// Returns the order list result and any error
+func (c *Client) ListPendingFulfillment(query *ListOrdersQuery) (*ListResult, error) {
+	var q ListOrdersQuery
+	if query != nil {
+		q = *query
+	}
+	q.Status = "paid"
+	q.FulfillmentStatus = FulfillmentStatusUnfulfilled
+
+	result, err := c.ListAppOrders(&q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders pending fulfillment: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package wordgate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestShipOrderValidatesShipmentLocally(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"code":0,"data":{}}`))
+	}))
+	defer server.Close()
+	client := NewClient("app", "secret", server.URL)
+
+	for _, request := range []*ShipOrderRequest{
+		nil,
+		{TrackingNumber: "SF123"},
+		{Carrier: "SF Express"},
+	} {
+		if _, err := client.ShipOrder("WG1", request); err == nil {
+			t.Errorf("ShipOrder(%+v) succeeded, want a validation error", request)
+		}
+	}
+	if requests != 0 {
+		t.Fatalf("invalid shipments sent %d requests", requests)
+	}
+}
+
+func TestFulfillmentRequestsAndErrors(t *testing.T) {
+	// Orders answer ship and deliver requests with these business codes
+	codes := map[string]int{
+		"WG-UNPAID":    40903,
+		"WG-DIGITAL":   40906,
+		"WG-SHIPPED":   40907,
+		"WG-UNSHIPPED": 40908,
+	}
+	var lastPath string
+	var lastBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastPath = r.Method + " " + r.URL.RequestURI()
+		lastBody = nil
+		json.NewDecoder(r.Body).Decode(&lastBody)
+		for orderNo, code := range codes {
+			if r.URL.Path == "/app/orders/"+orderNo+"/ship" || r.URL.Path == "/app/orders/"+orderNo+"/deliver" {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, `{"code":%d,"msg":"rejected"}`, code)
+				return
+			}
+		}
+		w.Write([]byte(`{"code":0,"data":{"order_no":"WG1","fulfillment_status":"shipped"}}`))
+	}))
+	defer server.Close()
+	client := NewClient("app", "secret", server.URL)
+
+	order, err := client.ShipOrder("WG1", &ShipOrderRequest{Carrier: "SF Express", TrackingNumber: "SF123"})
+	if err != nil || order.FulfillmentStatus != FulfillmentStatusShipped {
+		t.Fatalf("ShipOrder() = %+v, %v", order, err)
+	}
+	if lastPath != "POST /app/orders/WG1/ship" || lastBody["carrier"] != "SF Express" || lastBody["tracking_number"] != "SF123" {
+		t.Fatalf("ShipOrder sent %s %v", lastPath, lastBody)
+	}
+	if _, err := client.MarkOrderDelivered("WG1", nil); err != nil || lastPath != "POST /app/orders/WG1/deliver" {
+		t.Fatalf("MarkOrderDelivered() sent %s, error %v", lastPath, err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"ship unpaid", func() error {
+			_, err := client.ShipOrder("WG-UNPAID", &ShipOrderRequest{Carrier: "SF", TrackingNumber: "1"})
+			return err
+		}, ErrOrderNotPaid},
+		{"ship digital", func() error {
+			_, err := client.ShipOrder("WG-DIGITAL", &ShipOrderRequest{Carrier: "SF", TrackingNumber: "1"})
+			return err
+		}, ErrOrderNotShippable},
+		{"ship twice", func() error {
+			_, err := client.ShipOrder("WG-SHIPPED", &ShipOrderRequest{Carrier: "SF", TrackingNumber: "1"})
+			return err
+		}, ErrOrderAlreadyShipped},
+		{"deliver unshipped", func() error {
+			_, err := client.MarkOrderDelivered("WG-UNSHIPPED", nil)
+			return err
+		}, ErrOrderNotShipped},
+	}
+	sentinels := []error{ErrOrderNotPaid, ErrOrderNotShippable, ErrOrderAlreadyShipped, ErrOrderNotShipped}
+	for _, tt := range tests {
+		err := tt.call()
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+				t.Errorf("%s: errors.Is(%v, %v) = %v", tt.name, err, sentinel, got)
+			}
+		}
+	}
+}
+
+func TestListPendingFulfillmentOverridesFilters(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"code":0,"data":{"data":[],"pagination":{"page":2,"limit":10}}}`))
+	}))
+	defer server.Close()
+	client := NewClient("app", "secret", server.URL)
+
+	filters := &ListOrdersQuery{Page: 2, Limit: 10, Email: "a@example.com", Status: "unpaid", FulfillmentStatus: FulfillmentStatusShipped}
+	if _, err := client.ListPendingFulfillment(filters); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"page": "2", "limit": "10", "email": "a@example.com", "status": "paid", "fulfillment_status": "unfulfilled"}
+	for key, value := range want {
+		if got := query[key]; len(got) != 1 || got[0] != value {
+			t.Errorf("query %s = %v, want %s", key, got, value)
+		}
+	}
+	if filters.Status != "unpaid" || filters.FulfillmentStatus != FulfillmentStatusShipped {
+		t.Errorf("caller's query was modified: %+v", filters)
+	}
+
+	if _, err := client.ListPendingFulfillment(nil); err != nil {
+		t.Fatal(err)
+	}
+	if query.Get("status") != "paid" || query.Get("fulfillment_status") != "unfulfilled" {
+		t.Errorf("ListPendingFulfillment(nil) query = %v", query)
+	}
+}
@@ -115,6 +115,9 @@ type CreateMembershipTierRequest struct {
 
 // UpdateMembershipTierRequest represents a request to update a membership tier
 type UpdateMembershipTierRequest struct {
+	// Version is the expected current version; when set, the update fails with a
+	// VersionConflictError if the tier has changed since it was read (optional, use Ptr)
+	Version *int `json:"version,omitempty"`
 	// Name is the tier name
 	Name string `json:"name" binding:"required,max=100"`
 	// Level is the tier level (higher number = higher tier)
@@ -174,6 +177,9 @@ func (c *Client) GetMembershipTier(code string) (*MembershipTier, error) {
 
 // UpdateMembershipTier updates an existing membership tier
 //
+// When request.Version is set and the tier has a different version, a *VersionConflictError
+// is returned; compare with errors.Is(err, ErrVersionConflict) or use ModifyMembershipTier to retry.
+//
 // code: The tier code to update
 // request: The tier update request containing new tier details and pricing
 // Returns the updated tier information and any error
@@ -181,7 +187,9 @@ func (c *Client) UpdateMembershipTier(code string, request *UpdateMembershipTier
 	var result MembershipTier
 	path := fmt.Sprintf("/app/membership/tiers/%s", url.PathEscape(code))
 	err := c.requestJSON("PUT", path, request, &result)
+	c.TierCache.Invalidate(code)
 	if err != nil {
+		err = asVersionConflict(err, "membership tier", code, request.Version)
 		return nil, fmt.Errorf("failed to update membership tier: %w", err)
 	}
 	return &result, nil
@@ -195,6 +203,7 @@ func (c *Client) DeleteMembershipTier(code string) error {
 	var result map[string]interface{}
 	path := fmt.Sprintf("/app/membership/tiers/%s", url.PathEscape(code))
 	err := c.requestJSON("DELETE", path, nil, &result)
+	c.TierCache.Invalidate(code)
 	if err != nil {
 		return fmt.Errorf("failed to delete membership tier: %w", err)
 	}
@@ -209,6 +218,7 @@ func (c *Client) RestoreMembershipTier(code string) (*MembershipTier, error) {
 	var result MembershipTier
 	path := fmt.Sprintf("/app/membership/tiers/%s/restore", url.PathEscape(code))
 	err := c.requestJSON("POST", path, nil, &result)
+	c.TierCache.Invalidate(code)
 	if err != nil {
 		return nil, fmt.Errorf("failed to restore membership tier: %w", err)
 	}
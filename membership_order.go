@@ -0,0 +1,193 @@
+package wordgate
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Errors returned when a membership order is rejected locally before it is sent
+var (
+	// ErrUnknownPeriodType indicates the period type is not one of the PeriodType constants
+	ErrUnknownPeriodType = errors.New("unknown membership period type")
+	// ErrMembershipTierUnavailable indicates the tier is inactive or deleted
+	ErrMembershipTierUnavailable = errors.New("membership tier is not available")
+	// ErrMembershipPriceNotFound indicates the tier has no price for the period type
+	ErrMembershipPriceNotFound = errors.New("membership tier has no price for period")
+)
+
+// DefaultMembershipTierCacheTTL is how long NewClient caches membership tiers
+const DefaultMembershipTierCacheTTL = 5 * time.Minute
+
+// MembershipTierCache caches membership tiers by code
+//
+// Tiers updated, deleted or restored through the owning client are invalidated automatically.
+type MembershipTierCache struct {
+	// TTL is how long a fetched tier is reused (0 disables expiry)
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]membershipTierCacheEntry
+}
+
+// membershipTierCacheEntry is a cached tier with its fetch time
+type membershipTierCacheEntry struct {
+	tier      MembershipTier
+	fetchedAt time.Time
+}
+
+// NewMembershipTierCache creates an empty tier cache
+//
+// ttl: How long a fetched tier is reused (0 disables expiry)
+func NewMembershipTierCache(ttl time.Duration) *MembershipTierCache {
+	return &MembershipTierCache{
+		TTL:     ttl,
+		entries: make(map[string]membershipTierCacheEntry),
+	}
+}
+
+// Get returns the cached tier, or fetches it with fetch and caches the result
+//
+// code: The tier code
+// fetch: Fetches the tier when it is not cached or has expired
+// Returns the tier and any fetch error
+func (c *MembershipTierCache) Get(code string, fetch func(code string) (*MembershipTier, error)) (*MembershipTier, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[code]
+	c.mu.Unlock()
+	if ok && (c.TTL <= 0 || time.Since(entry.fetchedAt) < c.TTL) {
+		tier := entry.tier
+		return &tier, nil
+	}
+
+	tier, err := fetch(code)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]membershipTierCacheEntry)
+	}
+	c.entries[code] = membershipTierCacheEntry{tier: *tier, fetchedAt: time.Now()}
+	c.mu.Unlock()
+	return tier, nil
+}
+
+// Invalidate removes a tier from the cache; a nil cache is a no-op
+func (c *MembershipTierCache) Invalidate(code string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, code)
+}
+
+// Clear removes all tiers from the cache
+func (c *MembershipTierCache) Clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]membershipTierCacheEntry)
+}
+
+// IsValid reports whether the period type is one of the PeriodType constants
+func (p MembershipPeriodType) IsValid() bool {
+	return GetMonthsByPeriodType(p) > 0
+}
+
+// PriceFor returns the tier's price for a period type
+//
+// periodType: The membership period type
+// Returns the price, or nil if the tier has no price for the period
+func (t *MembershipTier) PriceFor(periodType MembershipPeriodType) *MembershipPrice {
+	for i := range t.Prices {
+		if t.Prices[i].PeriodType == periodType {
+			return &t.Prices[i]
+		}
+	}
+	return nil
+}
+
+// CreateTierMembershipOrderRequest represents a membership order keyed by tier code
+type CreateTierMembershipOrderRequest struct {
+	// TierCode is the membership tier code
+	TierCode string
+	// PeriodType is the membership period type
+	PeriodType MembershipPeriodType
+	// UserUID is the user's unique identifier
+	UserUID string
+	// CouponCode is an optional coupon code
+	CouponCode string
+	// ClientIP is the client's IP address (optional)
+	ClientIP string
+	// AddressID is the shipping address ID (optional)
+	AddressID uint64
+	// RedirectURL is the payment completion redirect URL (optional)
+	RedirectURL string
+	// ExternalRef is the caller's own reference for the order (optional)
+	ExternalRef string
+	// Metadata is arbitrary key/value data stored with the order (optional)
+	Metadata map[string]string
+}
+
+// ResolveTierMembershipOrder resolves the tier of a membership order and checks it can be ordered
+//
+// The tier is read through Client.TierCache when set. The request is rejected locally when the
+// period type is unknown, the tier is inactive or deleted, or the tier has no price for the period.
+//
+// request: The membership order keyed by tier code
+// Returns the equivalent CreateAppMembershipOrderRequest and any error
+func (c *Client) ResolveTierMembershipOrder(request *CreateTierMembershipOrderRequest) (*CreateAppMembershipOrderRequest, error) {
+	if request.TierCode == "" {
+		return nil, fmt.Errorf("tier code is required")
+	}
+	if !request.PeriodType.IsValid() {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPeriodType, request.PeriodType)
+	}
+
+	var tier *MembershipTier
+	var err error
+	if c.TierCache != nil {
+		tier, err = c.TierCache.Get(request.TierCode, c.GetMembershipTier)
+	} else {
+		tier, err = c.GetMembershipTier(request.TierCode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if tier.DeletedAt != nil || tier.Status != MembershipTierStatusActive {
+		return nil, fmt.Errorf("%w: %s", ErrMembershipTierUnavailable, tier.Code)
+	}
+	if tier.PriceFor(request.PeriodType) == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrMembershipPriceNotFound, tier.Code, request.PeriodType)
+	}
+
+	return &CreateAppMembershipOrderRequest{
+		TierID:      tier.ID,
+		PeriodType:  string(request.PeriodType),
+		CouponCode:  request.CouponCode,
+		ClientIP:    request.ClientIP,
+		AddressID:   request.AddressID,
+		UserUID:     request.UserUID,
+		RedirectURL: request.RedirectURL,
+		ExternalRef: request.ExternalRef,
+		Metadata:    request.Metadata,
+	}, nil
+}
+
+// CreateTierMembershipOrder creates a membership order keyed by tier code and typed period
+//
+// request: The membership order keyed by tier code
+// Returns the created order information and any error, see ResolveTierMembershipOrder for local checks
+func (c *Client) CreateTierMembershipOrder(request *CreateTierMembershipOrderRequest) (*OrderSummaryResponse, error) {
+	resolved, err := c.ResolveTierMembershipOrder(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create membership order: %w", err)
+	}
+	return c.CreateAppMembershipOrder(resolved)
+}
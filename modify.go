@@ -0,0 +1,125 @@
+package wordgate
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxModifyAttempts is how many times ModifyProduct and ModifyMembershipTier read and
+// update before giving up on repeated version conflicts
+const MaxModifyAttempts = 5
+
+// VersionConflictError indicates an update was rejected because the resource changed after it was read
+//
+// It unwraps to ErrVersionConflict, so errors.Is(err, ErrVersionConflict) matches it.
+type VersionConflictError struct {
+	// Resource is the resource type (e.g., "product")
+	Resource string
+	// Code is the resource code
+	Code string
+	// Version is the version the update expected
+	Version int
+	// Err is the API error returned by the server
+	Err error
+}
+
+// Error implements the error interface for VersionConflictError
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s %s was modified concurrently (expected version %d)", e.Resource, e.Code, e.Version)
+}
+
+// Unwrap returns the API error returned by the server
+func (e *VersionConflictError) Unwrap() error {
+	return e.Err
+}
+
+// asVersionConflict turns a version conflict API error into a *VersionConflictError
+// when the request carried a version
+func asVersionConflict(err error, resource, code string, version *int) error {
+	if version == nil || !errors.Is(err, ErrVersionConflict) {
+		return err
+	}
+	return &VersionConflictError{Resource: resource, Code: code, Version: *version, Err: err}
+}
+
+// ModifyProduct reads a product, applies modify and writes it back with its version,
+// retrying from a fresh read when another update wins the race
+//
+// Usage example:
+//
+//	product, err := client.ModifyProduct("PROD001", func(p *wordgate.Product) error {
+//		p.Price = p.Price * 9 / 10
+//		return nil
+//	})
+//
+// code: The product code to modify
+// modify: Changes Name, Price and RequireAddress in place; an error aborts without updating.
+// It may be called once per attempt and must not keep state between calls.
+// Returns the updated product, or the last *VersionConflictError after MaxModifyAttempts attempts
+func (c *Client) ModifyProduct(code string, modify func(product *Product) error) (*Product, error) {
+	var err error
+	for attempt := 0; attempt < MaxModifyAttempts; attempt++ {
+		var product *Product
+		product, err = c.GetProduct(code)
+		if err != nil {
+			return nil, err
+		}
+		if err := modify(product); err != nil {
+			return nil, err
+		}
+
+		var updated *Product
+		updated, err = c.UpdateProduct(code, &UpdateProductRequest{
+			Version:        Ptr(product.Version),
+			Name:           product.Name,
+			Price:          product.Price,
+			RequireAddress: product.RequireAddress,
+		})
+		if !errors.Is(err, ErrVersionConflict) {
+			return updated, err
+		}
+	}
+	return nil, err
+}
+
+// ModifyMembershipTier reads a membership tier, applies modify and writes it back with its
+// version, retrying from a fresh read when another update wins the race
+//
+// code: The tier code to modify
+// modify: Changes Name, Level, IsDefault and Prices in place; an error aborts without updating.
+// It may be called once per attempt and must not keep state between calls.
+// Returns the updated tier, or the last *VersionConflictError after MaxModifyAttempts attempts
+func (c *Client) ModifyMembershipTier(code string, modify func(tier *MembershipTier) error) (*MembershipTier, error) {
+	var err error
+	for attempt := 0; attempt < MaxModifyAttempts; attempt++ {
+		var tier *MembershipTier
+		tier, err = c.GetMembershipTier(code)
+		if err != nil {
+			return nil, err
+		}
+		if err := modify(tier); err != nil {
+			return nil, err
+		}
+
+		prices := make([]MembershipPriceRequest, len(tier.Prices))
+		for i, price := range tier.Prices {
+			prices[i] = MembershipPriceRequest{
+				PeriodType:    price.PeriodType,
+				Price:         price.Price,
+				OriginalPrice: price.OriginalPrice,
+			}
+		}
+		var updated *MembershipTier
+		updated, err = c.UpdateMembershipTier(code, &UpdateMembershipTierRequest{
+			Version:   Ptr(tier.Version),
+			Name:      tier.Name,
+			Level:     tier.Level,
+			IsDefault: tier.IsDefault,
+			Prices:    prices,
+		})
+		if !errors.Is(err, ErrVersionConflict) {
+			return updated, err
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,38 @@
+package wordgate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateProductSendsVersionZero(t *testing.T) {
+	var sent map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = nil
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"code":40909,"msg":"version conflict"}`))
+	}))
+	defer server.Close()
+	client := NewClient("app", "secret", server.URL)
+
+	_, err := client.UpdateProduct("PROD001", &UpdateProductRequest{Version: Ptr(0), Name: "Course"})
+	if version, ok := sent["version"]; !ok || version != float64(0) {
+		t.Fatalf("sent version = %v (present %v), want 0", version, ok)
+	}
+	var conflict *VersionConflictError
+	if !errors.As(err, &conflict) || conflict.Version != 0 {
+		t.Fatalf("error = %v, want VersionConflictError for version 0", err)
+	}
+
+	_, err = client.UpdateProduct("PROD001", &UpdateProductRequest{Name: "Course"})
+	if _, ok := sent["version"]; ok {
+		t.Fatalf("sent version without Version set: %v", sent)
+	}
+	if errors.As(err, &conflict) || !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("error = %v, want a plain ErrVersionConflict API error", err)
+	}
+}
@@ -1,7 +1,13 @@
 package wordgate
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -35,10 +41,7 @@ type OrderSummaryResponse struct {
 // CreateAppProductOrderRequest represents a request to create a product order via app admin API
 type CreateAppProductOrderRequest struct {
 	// Items is the list of product items
-	Items []struct {
-		ItemCode string `json:"item_code"`
-		Quantity int    `json:"quantity"`
-	} `json:"items"`
+	Items []OrderItem `json:"items"`
 	// CouponCode is an optional coupon code
 	CouponCode string `json:"coupon_code,omitempty"`
 	// ClientIP is the client's IP address (optional)
@@ -49,6 +52,10 @@ type CreateAppProductOrderRequest struct {
 	UserUID string `json:"user_uid"`
 	// RedirectURL is the payment completion redirect URL (optional)
 	RedirectURL string `json:"redirect_url,omitempty"`
+	// ExternalRef is the caller's own reference for the order, e.g. a cart ID (optional)
+	ExternalRef string `json:"external_ref,omitempty"`
+	// Metadata is arbitrary key/value data stored with the order (optional)
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // CreateAppMembershipOrderRequest represents a request to create a membership order via app admin API
@@ -67,6 +74,10 @@ type CreateAppMembershipOrderRequest struct {
 	UserUID string `json:"user_uid"`
 	// RedirectURL is the payment completion redirect URL (optional)
 	RedirectURL string `json:"redirect_url,omitempty"`
+	// ExternalRef is the caller's own reference for the order, e.g. a cart ID (optional)
+	ExternalRef string `json:"external_ref,omitempty"`
+	// Metadata is arbitrary key/value data stored with the order (optional)
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // CreateAppProductOrder creates a new product order using admin API
@@ -113,6 +124,12 @@ type ListOrdersQuery struct {
 	EndAt string `form:"end_at"`
 	// OrderNo filters orders by order number (partial match)
 	OrderNo string `form:"order_no"`
+	// ExternalRef filters orders by external reference (exact match)
+	ExternalRef string `form:"external_ref"`
+	// Metadata filters orders whose metadata contains all of these key/value pairs
+	Metadata map[string]string `form:"metadata"`
+	// FulfillmentStatus filters orders by fulfillment status
+	FulfillmentStatus FulfillmentStatus `form:"fulfillment_status"`
 	// SortBy specifies the field to sort by (created_at/amount)
 	SortBy string `form:"sort_by"`
 	// SortDesc specifies whether to sort in descending order
@@ -207,6 +224,14 @@ type OrderDetailResponse struct {
 	PaymentIntents []PaymentIntentInfo `json:"payment_intents"`
 	// User is the user information
 	User interface{} `json:"user,omitempty"`
+	// ExternalRef is the caller's own reference for the order
+	ExternalRef string `json:"external_ref,omitempty"`
+	// Metadata is the key/value data stored with the order
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// FulfillmentStatus is the shipping status of the order
+	FulfillmentStatus FulfillmentStatus `json:"fulfillment_status"`
+	// Shipment is the shipping information (nil if not shipped)
+	Shipment *Shipment `json:"shipment"`
 }
 
 // OrderListItem represents an order item in the list
@@ -237,6 +262,12 @@ type OrderListItem struct {
 	UserInfo interface{} `json:"user_info,omitempty"`
 	// RequireAddress indicates if the order requires shipping address
 	RequireAddress bool `json:"require_address"`
+	// ExternalRef is the caller's own reference for the order
+	ExternalRef string `json:"external_ref,omitempty"`
+	// Metadata is the key/value data stored with the order
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// FulfillmentStatus is the shipping status of the order
+	FulfillmentStatus FulfillmentStatus `json:"fulfillment_status"`
 }
 
 // ListResult represents a paginated list result
@@ -267,6 +298,60 @@ type ManualPaymentRequest struct {
 	PaymentNote string `json:"payment_note"`
 	// Amount is the payment amount in cents (optional, defaults to order amount)
 	Amount *int64 `json:"amount,omitempty"`
+	// Operator identifies who marked the order as paid, recorded for audit (required)
+	Operator string `json:"operator"`
+}
+
+// ManualPaymentResponse represents the result of manually marking an order as paid
+type ManualPaymentResponse struct {
+	// Order is the updated order details
+	Order OrderDetailResponse `json:"order"`
+	// PaymentIntent is the payment intent recording the manual payment
+	PaymentIntent PaymentIntentInfo `json:"payment_intent"`
+}
+
+// ManualPaymentRecord represents an audit record of a manual payment
+type ManualPaymentRecord struct {
+	// ID is the record ID
+	ID uint64 `json:"id"`
+	// OrderNo is the order number
+	OrderNo string `json:"order_no"`
+	// Amount is the recorded payment amount in cents
+	Amount int64 `json:"amount"`
+	// Currency is the currency code
+	Currency string `json:"currency"`
+	// PaymentNote is the payment note
+	PaymentNote string `json:"payment_note"`
+	// Operator identifies who marked the order as paid
+	Operator string `json:"operator"`
+	// PaymentIntentID is the ID of the payment intent created for the manual payment
+	PaymentIntentID string `json:"payment_intent_id"`
+	// CreatedAt is when the order was marked as paid
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListManualPaymentsQuery represents query parameters for listing manual payment records
+type ListManualPaymentsQuery struct {
+	// Page is the page number (starting from 1)
+	Page int `json:"page,omitempty"`
+	// Limit is the number of items per page
+	Limit int `json:"limit,omitempty"`
+	// OrderNo filters records by order number (optional)
+	OrderNo string `json:"order_no,omitempty"`
+	// Operator filters records by operator (optional)
+	Operator string `json:"operator,omitempty"`
+	// StartAt filters records created after this date (YYYY-MM-DD)
+	StartAt string `json:"start_at,omitempty"`
+	// EndAt filters records created before this date (YYYY-MM-DD)
+	EndAt string `json:"end_at,omitempty"`
+}
+
+// ManualPaymentListResponse represents a paginated list of manual payment records
+type ManualPaymentListResponse struct {
+	// Data is the list of manual payment records
+	Data []ManualPaymentRecord `json:"data"`
+	// Pagination contains pagination information
+	Pagination PaginationInfo `json:"pagination"`
 }
 
 // GetAppOrder retrieves detailed order information by order number
@@ -274,36 +359,344 @@ type ManualPaymentRequest struct {
 // orderNo: The order number to retrieve
 // Returns the detailed order information and any error
 func (c *Client) GetAppOrder(orderNo string) (*OrderDetailResponse, error) {
+	return c.GetAppOrderContext(context.Background(), orderNo)
+}
+
+// GetAppOrderContext retrieves detailed order information by order number, bound to a context
+//
+// ctx: Context controlling cancellation of the request
+// orderNo: The order number to retrieve
+// Returns the detailed order information and any error
+func (c *Client) GetAppOrderContext(ctx context.Context, orderNo string) (*OrderDetailResponse, error) {
 	var result OrderDetailResponse
-	err := c.requestJSON("GET", "/app/orders/"+orderNo, nil, &result)
+	err := c.requestJSONContext(ctx, "GET", "/app/orders/"+orderNo, nil, &result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get app order: %w", err)
 	}
 	return &result, nil
 }
 
+// GetAppOrderByExternalRef retrieves detailed order information by the caller's external reference
+//
+// externalRef: The external reference set when the order was created
+// Returns the detailed order information, or ErrOrderNotFound if no order has the reference
+func (c *Client) GetAppOrderByExternalRef(externalRef string) (*OrderDetailResponse, error) {
+	if externalRef == "" {
+		return nil, fmt.Errorf("failed to get app order by external reference: external reference is required")
+	}
+
+	result, err := c.ListAppOrders(&ListOrdersQuery{Page: 1, Limit: 1, ExternalRef: externalRef})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app order by external reference: %w", err)
+	}
+	var items []OrderListItem
+	if err := result.Decode(&items); err != nil {
+		return nil, fmt.Errorf("failed to get app order by external reference: %w", err)
+	}
+	if len(items) == 0 || items[0].ExternalRef != externalRef {
+		return nil, fmt.Errorf("failed to get app order by external reference %s: %w", externalRef, ErrOrderNotFound)
+	}
+	return c.GetAppOrder(items[0].OrderNo)
+}
+
 // ListAppOrders retrieves a paginated list of orders with optional filtering
 //
 // query: The query parameters for filtering and pagination
 // Returns the order list result and any error
 func (c *Client) ListAppOrders(query *ListOrdersQuery) (*ListResult, error) {
+	// Build query parameters
+	params := url.Values{}
+
+	if query != nil {
+		if query.Page > 0 {
+			params.Set("page", strconv.Itoa(query.Page))
+		}
+		if query.Limit > 0 {
+			params.Set("limit", strconv.Itoa(query.Limit))
+		}
+		if query.Status != "" {
+			params.Set("status", query.Status)
+		}
+		if query.UserUID != "" {
+			params.Set("user_uid", query.UserUID)
+		}
+		if query.Email != "" {
+			params.Set("email", query.Email)
+		}
+		if query.StartAt != "" {
+			params.Set("start_at", query.StartAt)
+		}
+		if query.EndAt != "" {
+			params.Set("end_at", query.EndAt)
+		}
+		if query.OrderNo != "" {
+			params.Set("order_no", query.OrderNo)
+		}
+		if query.ExternalRef != "" {
+			params.Set("external_ref", query.ExternalRef)
+		}
+		for key, value := range query.Metadata {
+			params.Set("metadata["+key+"]", value)
+		}
+		if query.FulfillmentStatus != "" {
+			params.Set("fulfillment_status", string(query.FulfillmentStatus))
+		}
+		if query.SortBy != "" {
+			params.Set("sort_by", query.SortBy)
+		}
+		if query.SortDesc {
+			params.Set("sort_desc", "true")
+		}
+	}
+
+	// Build path with query parameters
+	path := "/app/orders"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
 	var result ListResult
-	err := c.requestJSON("GET", "/app/orders", query, &result)
+	err := c.requestJSON("GET", path, nil, &result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list app orders: %w", err)
 	}
 	return &result, nil
 }
 
+// Decode unmarshals the list data into the target slice (e.g. *[]OrderListItem)
+//
+// target: Pointer to the slice to decode into
+// Returns any error encountered during decoding
+func (r *ListResult) Decode(target interface{}) error {
+	if r.Data == nil {
+		return nil
+	}
+
+	dataBytes, err := json.Marshal(r.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal list data: %w", err)
+	}
+	if err := json.Unmarshal(dataBytes, target); err != nil {
+		return fmt.Errorf("failed to unmarshal list data: %w", err)
+	}
+	return nil
+}
+
+// OrderIterator iterates over all orders matching a query, fetching pages on demand
+type OrderIterator struct {
+	client *Client
+	query  ListOrdersQuery
+	items  []OrderListItem
+	index  int
+	done   bool
+	err    error
+}
+
+// IterateAppOrders returns an iterator over all orders matching the query
+//
+// query: The query parameters for filtering; Page sets the first page to fetch
+// (defaults to 1) and Limit the page size (defaults to 100)
+// Returns the order iterator
+func (c *Client) IterateAppOrders(query *ListOrdersQuery) *OrderIterator {
+	it := &OrderIterator{client: c}
+	if query != nil {
+		it.query = *query
+	}
+	if it.query.Page < 1 {
+		it.query.Page = 1
+	}
+	if it.query.Limit < 1 {
+		it.query.Limit = 100
+	}
+	// Page is advanced before each fetch
+	it.query.Page--
+	return it
+}
+
+// Next advances the iterator to the next order, fetching the next page when needed
+//
+// Returns false when there are no more orders or an error occurred (see Err)
+func (it *OrderIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.index >= len(it.items) {
+		if it.done {
+			return false
+		}
+		if !it.fetch() {
+			return false
+		}
+	}
+	return true
+}
+
+// Order returns the current order
+func (it *OrderIterator) Order() *OrderListItem {
+	if it.index < 0 || it.index >= len(it.items) {
+		return nil
+	}
+	return &it.items[it.index]
+}
+
+// Page returns the page number the current order was fetched from
+func (it *OrderIterator) Page() int {
+	return it.query.Page
+}
+
+// Err returns the first error encountered while fetching pages
+func (it *OrderIterator) Err() error {
+	return it.err
+}
+
+// fetch loads the next page of orders
+func (it *OrderIterator) fetch() bool {
+	it.query.Page++
+	result, err := it.client.ListAppOrders(&it.query)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	var items []OrderListItem
+	if err := result.Decode(&items); err != nil {
+		it.err = fmt.Errorf("failed to decode app orders: %w", err)
+		return false
+	}
+
+	it.items = items
+	it.index = 0
+	if len(items) < it.query.Limit ||
+		(result.Pagination != nil && it.query.Page >= result.Pagination.TotalPages) {
+		it.done = true
+	}
+	return len(items) > 0
+}
+
+// ErrOrderNotFound is returned by GetAppOrderByExternalRef when no order has the reference
+var ErrOrderNotFound = errors.New("order not found")
+
+// Errors returned by MarkOrderAsPaid when the request is rejected locally before it is sent
+var (
+	// ErrManualPaymentExceedsTotal indicates the manual payment amount exceeds the order total
+	ErrManualPaymentExceedsTotal = errors.New("manual payment amount exceeds order total")
+	// ErrManualPaymentOrderPaid indicates the order was already paid when it was checked
+	ErrManualPaymentOrderPaid = errors.New("order is already paid")
+)
+
 // MarkOrderAsPaid manually marks an order as paid
 //
-// request: The manual payment request containing order number and payment note
-// Returns any error
-func (c *Client) MarkOrderAsPaid(request *ManualPaymentRequest) error {
-	var result interface{}
+// The request is validated locally first: PaymentNote and Operator are required, and when
+// Amount is set the order is fetched to check that it is positive and does not exceed the
+// order total (ErrManualPaymentExceedsTotal) and that the order is not paid yet
+// (ErrManualPaymentOrderPaid). The API returns ErrOrderAlreadyPaid if the order is paid
+// by the time the request arrives.
+//
+// request: The manual payment request containing order number, payment note and operator
+// Returns the updated order and the payment intent recording the payment, and any error
+func (c *Client) MarkOrderAsPaid(request *ManualPaymentRequest) (*ManualPaymentResponse, error) {
+	if request == nil || request.OrderNo == "" {
+		return nil, fmt.Errorf("failed to mark order as paid: order number is required")
+	}
+	if strings.TrimSpace(request.PaymentNote) == "" {
+		return nil, fmt.Errorf("failed to mark order as paid: payment note is required")
+	}
+	if strings.TrimSpace(request.Operator) == "" {
+		return nil, fmt.Errorf("failed to mark order as paid: operator is required")
+	}
+	if request.Amount != nil {
+		if *request.Amount <= 0 {
+			return nil, fmt.Errorf("failed to mark order as paid: invalid amount %d", *request.Amount)
+		}
+		order, err := c.GetAppOrder(request.OrderNo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mark order as paid: %w", err)
+		}
+		if order.IsPaid {
+			return nil, fmt.Errorf("failed to mark order as paid: %w", ErrManualPaymentOrderPaid)
+		}
+		if *request.Amount > order.Amount {
+			return nil, fmt.Errorf("failed to mark order as paid: %w: %d > %d",
+				ErrManualPaymentExceedsTotal, *request.Amount, order.Amount)
+		}
+	}
+
+	var result ManualPaymentResponse
 	err := c.requestJSON("POST", "/app/orders/mark_as_paid", request, &result)
 	if err != nil {
-		return fmt.Errorf("failed to mark order as paid: %w", err)
+		return nil, fmt.Errorf("failed to mark order as paid: %w", err)
 	}
-	return nil
+	return &result, nil
+}
+
+// ListManualPayments retrieves a paginated list of manual payment records for audit
+//
+// query: The query parameters for filtering and pagination (optional)
+// Returns the manual payment records with pagination information and any error
+func (c *Client) ListManualPayments(query *ListManualPaymentsQuery) (*ManualPaymentListResponse, error) {
+	// Build query parameters
+	params := url.Values{}
+
+	if query != nil {
+		if query.Page > 0 {
+			params.Set("page", strconv.Itoa(query.Page))
+		}
+		if query.Limit > 0 {
+			params.Set("limit", strconv.Itoa(query.Limit))
+		}
+		if query.OrderNo != "" {
+			params.Set("order_no", query.OrderNo)
+		}
+		if query.Operator != "" {
+			params.Set("operator", query.Operator)
+		}
+		if query.StartAt != "" {
+			params.Set("start_at", query.StartAt)
+		}
+		if query.EndAt != "" {
+			params.Set("end_at", query.EndAt)
+		}
+	}
+
+	// Build path with query parameters
+	path := "/app/orders/manual-payments"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var result ManualPaymentListResponse
+	err := c.requestJSON("GET", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list manual payments: %w", err)
+	}
+	return &result, nil
+}
+
+// CancelOrderRequest represents a request to cancel an unpaid order
+type CancelOrderRequest struct {
+	// Reason is the cancellation reason (optional)
+	Reason string `json:"reason,omitempty"`
+}
+
+// CancelOrder cancels an unpaid order
+//
+// Returns ErrOrderAlreadyCancelled if the order was cancelled before and
+// ErrOrderAlreadyPaid if the order is paid (use RefundOrder instead); compare with errors.Is
+//
+// orderNo: The order number to cancel
+// request: The cancellation request (optional)
+// Returns the cancelled order details and any error
+func (c *Client) CancelOrder(orderNo string, request *CancelOrderRequest) (*OrderDetailResponse, error) {
+	if request == nil {
+		request = &CancelOrderRequest{}
+	}
+
+	var result OrderDetailResponse
+	path := fmt.Sprintf("/app/orders/%s/cancel", url.PathEscape(orderNo))
+	err := c.requestJSON("POST", path, request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+	return &result, nil
 }
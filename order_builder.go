@@ -0,0 +1,198 @@
+package wordgate
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrAddressRequired indicates the order contains products that require a shipping address
+var ErrAddressRequired = errors.New("shipping address is required")
+
+// OrderBuilder builds and validates a product order request step by step
+//
+// Usage example:
+//
+//	order, err := client.NewOrderBuilder("user123").
+//		AddItem("PREMIUM_PLAN", 1).
+//		AddItem("ADDON_SERVICE", 2).
+//		SetCoupon("DISCOUNT10").
+//		SetRedirectURL("https://yoursite.com/payment/success").
+//		Create()
+type OrderBuilder struct {
+	client         *Client
+	request        CreateAppProductOrderRequest
+	defaultAddress bool
+	fallback       *UserAddressRequest
+	err            error
+}
+
+// NewOrderBuilder starts building a product order for a user
+//
+// userUID: The user's unique identifier
+func (c *Client) NewOrderBuilder(userUID string) *OrderBuilder {
+	return &OrderBuilder{
+		client:  c,
+		request: CreateAppProductOrderRequest{UserUID: userUID},
+	}
+}
+
+// AddItem adds a product to the order; adding the same product again increases its quantity
+//
+// itemCode: The product code
+// quantity: The number of items (must be positive)
+func (b *OrderBuilder) AddItem(itemCode string, quantity int) *OrderBuilder {
+	if itemCode == "" {
+		b.fail(fmt.Errorf("item code is required"))
+		return b
+	}
+	if quantity <= 0 {
+		b.fail(fmt.Errorf("invalid quantity %d for item %s", quantity, itemCode))
+		return b
+	}
+
+	for i := range b.request.Items {
+		if b.request.Items[i].ItemCode == itemCode {
+			b.request.Items[i].Quantity += quantity
+			return b
+		}
+	}
+	b.request.Items = append(b.request.Items, OrderItem{ItemCode: itemCode, Quantity: quantity})
+	return b
+}
+
+// SetCoupon sets the coupon code applied to the order
+func (b *OrderBuilder) SetCoupon(code string) *OrderBuilder {
+	b.request.CouponCode = code
+	return b
+}
+
+// SetAddress sets the shipping address ID
+func (b *OrderBuilder) SetAddress(addressID uint64) *OrderBuilder {
+	b.request.AddressID = addressID
+	return b
+}
+
+// SetDefaultAddress uses the user's default address when a product requires shipping and no
+// address was set, creating fallback as the default address if the user has none
+//
+// fallback: The address to create when the user has none (optional)
+func (b *OrderBuilder) SetDefaultAddress(fallback *UserAddressRequest) *OrderBuilder {
+	b.defaultAddress = true
+	b.fallback = fallback
+	return b
+}
+
+// SetRedirectURL sets the payment completion redirect URL
+func (b *OrderBuilder) SetRedirectURL(redirectURL string) *OrderBuilder {
+	b.request.RedirectURL = redirectURL
+	return b
+}
+
+// SetClientIP sets the client's IP address
+func (b *OrderBuilder) SetClientIP(clientIP string) *OrderBuilder {
+	b.request.ClientIP = clientIP
+	return b
+}
+
+// SetExternalRef sets the caller's own reference for the order
+func (b *OrderBuilder) SetExternalRef(externalRef string) *OrderBuilder {
+	b.request.ExternalRef = externalRef
+	return b
+}
+
+// SetMetadata sets a metadata key/value stored with the order
+func (b *OrderBuilder) SetMetadata(key, value string) *OrderBuilder {
+	if b.request.Metadata == nil {
+		b.request.Metadata = make(map[string]string)
+	}
+	b.request.Metadata[key] = value
+	return b
+}
+
+// Build validates the order and returns the request
+//
+// When no address is set, the products are fetched to check whether any of them requires a
+// shipping address; if so the default address is used when SetDefaultAddress was called, and
+// ErrAddressRequired is returned otherwise.
+//
+// Returns the validated request and any error
+func (b *OrderBuilder) Build() (*CreateAppProductOrderRequest, error) {
+	if b.err != nil {
+		return nil, fmt.Errorf("failed to build order: %w", b.err)
+	}
+	if b.request.UserUID == "" {
+		return nil, fmt.Errorf("failed to build order: user UID is required")
+	}
+	if len(b.request.Items) == 0 {
+		return nil, fmt.Errorf("failed to build order: order has no items")
+	}
+
+	request := b.request
+	request.Items = append([]OrderItem(nil), b.request.Items...)
+	if b.request.Metadata != nil {
+		request.Metadata = make(map[string]string, len(b.request.Metadata))
+		for key, value := range b.request.Metadata {
+			request.Metadata[key] = value
+		}
+	}
+
+	if request.AddressID == 0 {
+		required, err := b.requiresAddress()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build order: %w", err)
+		}
+		if required {
+			if !b.defaultAddress {
+				return nil, fmt.Errorf("failed to build order: %w", ErrAddressRequired)
+			}
+			if err := b.client.AssignDefaultAddress(&request, b.fallback); err != nil {
+				return nil, fmt.Errorf("failed to build order: %w", err)
+			}
+		}
+	}
+
+	return &request, nil
+}
+
+// Quote validates the order and previews its price without creating it
+//
+// Returns the order quote and any error
+func (b *OrderBuilder) Quote() (*OrderQuoteResponse, error) {
+	request, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return b.client.QuoteAppProductOrder(request)
+}
+
+// Create validates the order and creates it
+//
+// Returns the created order information and any error
+func (b *OrderBuilder) Create() (*OrderSummaryResponse, error) {
+	request, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return b.client.CreateAppProductOrder(request)
+}
+
+// requiresAddress reports whether any product in the order requires a shipping address
+func (b *OrderBuilder) requiresAddress() (bool, error) {
+	for _, item := range b.request.Items {
+		product, err := b.client.GetProduct(item.ItemCode)
+		if err != nil {
+			return false, err
+		}
+		if product.RequireAddress {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fail records the first error encountered while building
+func (b *OrderBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
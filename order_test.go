@@ -0,0 +1,72 @@
+package wordgate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMarkOrderAsPaidLocalChecks(t *testing.T) {
+	posted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posted = true
+		}
+		switch r.URL.Path {
+		case "/app/orders/PAID":
+			json.NewEncoder(w).Encode(APIResponse{Data: OrderDetailResponse{OrderNo: "PAID", Amount: 1000, IsPaid: true}})
+		default:
+			json.NewEncoder(w).Encode(APIResponse{Data: OrderDetailResponse{OrderNo: "UNPAID", Amount: 1000}})
+		}
+	}))
+	defer server.Close()
+	client := NewClient("app", "secret", server.URL)
+
+	if _, err := client.MarkOrderAsPaid(nil); err == nil {
+		t.Fatal("MarkOrderAsPaid(nil) returned no error")
+	}
+
+	amount := int64(1500)
+	_, err := client.MarkOrderAsPaid(&ManualPaymentRequest{OrderNo: "UNPAID", PaymentNote: "bank transfer", Operator: "admin", Amount: &amount})
+	if !errors.Is(err, ErrManualPaymentExceedsTotal) {
+		t.Fatalf("error = %v, want ErrManualPaymentExceedsTotal", err)
+	}
+	var apiErr APIError
+	if errors.As(err, &apiErr) {
+		t.Fatalf("local check returned APIError %v", apiErr)
+	}
+
+	amount = 500
+	_, err = client.MarkOrderAsPaid(&ManualPaymentRequest{OrderNo: "PAID", PaymentNote: "bank transfer", Operator: "admin", Amount: &amount})
+	if !errors.Is(err, ErrManualPaymentOrderPaid) {
+		t.Fatalf("error = %v, want ErrManualPaymentOrderPaid", err)
+	}
+
+	if posted {
+		t.Fatal("request rejected locally was sent to the API")
+	}
+}
+
+func TestGetAppOrderByExternalRefNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app/orders":
+			json.NewEncoder(w).Encode(APIResponse{Data: ListResult{Data: []OrderListItem{}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":404,"msg":"user not found"}`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient("app", "secret", server.URL)
+
+	if _, err := client.GetAppOrderByExternalRef("cart-1"); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("GetAppOrderByExternalRef() error = %v, want ErrOrderNotFound", err)
+	}
+	// Other 404 responses must not match ErrOrderNotFound
+	if _, err := client.GetUser("missing"); err == nil || errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("GetUser() error = %v, want a 404 API error that is not ErrOrderNotFound", err)
+	}
+}
@@ -0,0 +1,156 @@
+package wordgate
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Ptr returns a pointer to v, for setting optional fields of patch requests
+//
+// Usage example:
+//
+//	client.PatchProduct("PROD001", &wordgate.PatchProductRequest{RequireAddress: wordgate.Ptr(true)})
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// PatchProductRequest represents a partial update of a product; nil fields are left unchanged
+type PatchProductRequest struct {
+	// Version is the expected current version; when set, the update fails with a
+	// VersionConflictError if the product has changed since it was read (optional, use Ptr)
+	Version *int `json:"version,omitempty"`
+	// Name is the new product name (optional)
+	Name *string `json:"name,omitempty"`
+	// Price is the new product price in cents (optional)
+	Price *int64 `json:"price,omitempty"`
+	// RequireAddress sets whether this product requires shipping address (optional)
+	RequireAddress *bool `json:"require_address,omitempty"`
+}
+
+// MembershipPricePatch represents a change to the price of one period; other periods are left unchanged
+type MembershipPricePatch struct {
+	// PeriodType is the membership period type
+	PeriodType MembershipPeriodType `json:"period_type"`
+	// Price is the new discounted price in cents (optional)
+	Price *int64 `json:"price,omitempty"`
+	// OriginalPrice is the new original price in cents (optional)
+	OriginalPrice *int64 `json:"original_price,omitempty"`
+	// Remove removes the price for this period; Price and OriginalPrice must not be set
+	Remove bool `json:"remove,omitempty"`
+}
+
+// PatchMembershipTierRequest represents a partial update of a membership tier; nil fields are left unchanged
+type PatchMembershipTierRequest struct {
+	// Version is the expected current version; when set, the update fails with a
+	// VersionConflictError if the tier has changed since it was read (optional, use Ptr)
+	Version *int `json:"version,omitempty"`
+	// Name is the new tier name (optional)
+	Name *string `json:"name,omitempty"`
+	// Level is the new tier level (optional)
+	Level *int `json:"level,omitempty"`
+	// IsDefault sets whether this is the default tier (optional)
+	IsDefault *bool `json:"is_default,omitempty"`
+	// Prices are the per-period price changes; periods not listed keep their price (optional)
+	Prices []MembershipPricePatch `json:"prices,omitempty"`
+}
+
+// Validate checks that the patch changes something and that its values are in range
+func (r *PatchProductRequest) Validate() error {
+	if r == nil || (r.Name == nil && r.Price == nil && r.RequireAddress == nil) {
+		return fmt.Errorf("no fields to update")
+	}
+	if r.Name != nil && *r.Name == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+	if r.Price != nil && *r.Price < 0 {
+		return fmt.Errorf("price must not be negative")
+	}
+	return nil
+}
+
+// Validate checks that the patch changes something and that its values are in range
+//
+// Each period may be listed once with a known period type. A price whose Price and OriginalPrice
+// are both set must not exceed the original price; when only one is set the server checks it
+// against the stored value.
+func (r *PatchMembershipTierRequest) Validate() error {
+	if r == nil || (r.Name == nil && r.Level == nil && r.IsDefault == nil && len(r.Prices) == 0) {
+		return fmt.Errorf("no fields to update")
+	}
+	if r.Name != nil && *r.Name == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+	if r.Level != nil && *r.Level < 1 {
+		return fmt.Errorf("level must be at least 1")
+	}
+
+	periods := make(map[MembershipPeriodType]bool, len(r.Prices))
+	for _, price := range r.Prices {
+		switch {
+		case !price.PeriodType.IsValid():
+			return fmt.Errorf("%w: %q", ErrUnknownPeriodType, price.PeriodType)
+		case periods[price.PeriodType]:
+			return fmt.Errorf("duplicate price change for period %s", price.PeriodType)
+		case price.Remove && (price.Price != nil || price.OriginalPrice != nil):
+			return fmt.Errorf("price for period %s cannot be both removed and changed", price.PeriodType)
+		case !price.Remove && price.Price == nil && price.OriginalPrice == nil:
+			return fmt.Errorf("price change for period %s sets no fields", price.PeriodType)
+		case price.Price != nil && *price.Price < 0, price.OriginalPrice != nil && *price.OriginalPrice < 0:
+			return fmt.Errorf("price for period %s must not be negative", price.PeriodType)
+		case price.Price != nil && price.OriginalPrice != nil && *price.Price > *price.OriginalPrice:
+			return fmt.Errorf("price %d for period %s exceeds original price %d", *price.Price, price.PeriodType, *price.OriginalPrice)
+		}
+		periods[price.PeriodType] = true
+	}
+	return nil
+}
+
+// PatchProduct changes only the fields set in the request
+//
+// code: The product code to update
+// request: The fields to change
+// Returns the updated product information and any error
+func (c *Client) PatchProduct(code string, request *PatchProductRequest) (*Product, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to patch product: %w", err)
+	}
+
+	var result Product
+	path := fmt.Sprintf("/app/products/%s", url.PathEscape(code))
+	err := c.requestJSON("PATCH", path, request, &result)
+	if err != nil {
+		err = asVersionConflict(err, "product", code, request.Version)
+		return nil, fmt.Errorf("failed to patch product: %w", err)
+	}
+	return &result, nil
+}
+
+// PatchMembershipTier changes only the fields and period prices set in the request
+//
+// Usage example:
+//
+//	// Change the yearly price only
+//	tier, err := client.PatchMembershipTier("VIP", &wordgate.PatchMembershipTierRequest{
+//		Prices: []wordgate.MembershipPricePatch{
+//			{PeriodType: wordgate.PeriodTypeYear, Price: wordgate.Ptr(int64(19900))},
+//		},
+//	})
+//
+// code: The tier code to update
+// request: The fields and period prices to change
+// Returns the updated tier information and any error
+func (c *Client) PatchMembershipTier(code string, request *PatchMembershipTierRequest) (*MembershipTier, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to patch membership tier: %w", err)
+	}
+
+	var result MembershipTier
+	path := fmt.Sprintf("/app/membership/tiers/%s", url.PathEscape(code))
+	err := c.requestJSON("PATCH", path, request, &result)
+	c.TierCache.Invalidate(code)
+	if err != nil {
+		err = asVersionConflict(err, "membership tier", code, request.Version)
+		return nil, fmt.Errorf("failed to patch membership tier: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,149 @@
+package wordgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ErrPaymentWaitTimeout indicates the order was not paid before the wait timed out
+var ErrPaymentWaitTimeout = errors.New("timed out waiting for payment")
+
+// WaitForPaymentOptions configures WaitForPayment
+type WaitForPaymentOptions struct {
+	// InitialInterval is the delay before the second poll (defaults to 2 seconds)
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between polls (defaults to 30 seconds)
+	MaxInterval time.Duration
+	// Timeout limits the total wait in addition to the context deadline (optional)
+	Timeout time.Duration
+	// Bus lets order.paid and order.cancelled webhook events for the order end the wait
+	// early instead of waiting for the next poll (optional)
+	Bus *WebhookBus
+}
+
+// WaitForPayment waits until an order is paid, polling GetAppOrder with exponential backoff
+//
+// When opts.Bus is set, an order.paid webhook event for the order triggers an immediate
+// check, and an order.cancelled event ends the wait with ErrOrderAlreadyCancelled.
+// Transient errors (network failures, HTTP 5xx and 429) are retried; any other error, such as
+// an unknown order or an undecodable response, is returned immediately.
+//
+// ctx: Context controlling the wait; cancellation returns context.Canceled
+// orderNo: The order number to wait for
+// opts: Polling options (optional)
+// Returns the paid order details, or ErrPaymentWaitTimeout when the timeout or context deadline expires
+func (c *Client) WaitForPayment(ctx context.Context, orderNo string, opts *WaitForPaymentOptions) (*OrderDetailResponse, error) {
+	if opts == nil {
+		opts = &WaitForPaymentOptions{}
+	}
+	interval := opts.InitialInterval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 30 * time.Second
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	// Webhook events for this order wake up the loop
+	var paidEvents, cancelledEvents <-chan BusEvent
+	if opts.Bus != nil {
+		paid := opts.Bus.Subscribe(WebhookEventOrderPaid, &SubscribeOptions{Policy: BackpressureDropOldest})
+		defer paid.Unsubscribe()
+		cancelled := opts.Bus.Subscribe(WebhookEventOrderCancelled, &SubscribeOptions{Policy: BackpressureDropOldest})
+		defer cancelled.Unsubscribe()
+		paidEvents, cancelledEvents = paid.C(), cancelled.C()
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		woken := false
+		select {
+		case <-ctx.Done():
+			return nil, waitError(ctx)
+		case event, ok := <-cancelledEvents:
+			if !ok {
+				cancelledEvents = nil
+				continue
+			}
+			if data, _ := event.Payload.(*WebhookOrderCancelledData); data != nil && data.WordgateOrderNo == orderNo {
+				return nil, fmt.Errorf("failed to wait for payment: %w", ErrOrderAlreadyCancelled)
+			}
+			continue
+		case event, ok := <-paidEvents:
+			if !ok {
+				paidEvents = nil
+				continue
+			}
+			if data, _ := event.Payload.(*WebhookOrderPaidData); data == nil || data.WordgateOrderNo != orderNo {
+				continue
+			}
+			woken = true
+		case <-timer.C:
+		}
+
+		order, err := c.GetAppOrderContext(ctx, orderNo)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, waitError(ctx)
+			}
+			if !isTransientError(err) {
+				return nil, fmt.Errorf("failed to wait for payment: %w", err)
+			}
+		} else if order.IsPaid {
+			return order, nil
+		}
+
+		// Schedule the next poll; a webhook wakeup keeps the current interval
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(interval)
+		if !woken {
+			interval *= 2
+			if interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+	}
+}
+
+// isTransientError reports whether a failed request is worth retrying:
+// network failures, server errors (5xx) and rate limiting (429)
+func isTransientError(err error) bool {
+	status := 0
+	var apiErr APIError
+	var httpErr *HTTPError
+	switch {
+	case errors.As(err, &apiErr):
+		status = apiErr.StatusCode
+	case errors.As(err, &httpErr):
+		status = httpErr.StatusCode
+	default:
+		var netErr net.Error
+		return errors.As(err, &netErr)
+	}
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// waitError converts the context error into the error returned by WaitForPayment
+func waitError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("failed to wait for payment: %w", ErrPaymentWaitTimeout)
+	}
+	return fmt.Errorf("failed to wait for payment: %w", ctx.Err())
+}
@@ -0,0 +1,93 @@
+package wordgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForPaymentRetriesTransientErrors(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("upstream unavailable"))
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"code":429,"msg":"rate limited"}`))
+		case 3:
+			w.Write([]byte(`{"code":0,"data":{"order_no":"WG1","is_paid":false}}`))
+		default:
+			w.Write([]byte(`{"code":0,"data":{"order_no":"WG1","is_paid":true}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("app", "secret", server.URL)
+	order, err := client.WaitForPayment(context.Background(), "WG1", &WaitForPaymentOptions{
+		InitialInterval: time.Millisecond,
+		Timeout:         5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("WaitForPayment() error = %v", err)
+	}
+	if !order.IsPaid || requests.Load() != 4 {
+		t.Fatalf("order paid = %v after %d requests, want paid after 4", order.IsPaid, requests.Load())
+	}
+}
+
+func TestWaitForPaymentFailsFastOnPermanentErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "not found without envelope", status: http.StatusNotFound, body: "404 page not found"},
+		{name: "business error", status: http.StatusNotFound, body: `{"code":404,"msg":"order not found"}`},
+		{name: "undecodable response", status: http.StatusOK, body: "not json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient("app", "secret", server.URL)
+			_, err := client.WaitForPayment(context.Background(), "WG1", &WaitForPaymentOptions{
+				InitialInterval: time.Millisecond,
+				Timeout:         5 * time.Second,
+			})
+			if err == nil || errors.Is(err, ErrPaymentWaitTimeout) {
+				t.Fatalf("WaitForPayment() error = %v, want immediate failure", err)
+			}
+			if requests.Load() != 1 {
+				t.Fatalf("made %d requests, want 1", requests.Load())
+			}
+		})
+	}
+}
+
+func TestWaitForPaymentStopsOnCancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":0,"data":{"order_no":"WG1","is_paid":false}}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	client := NewClient("app", "secret", server.URL)
+	_, err := client.WaitForPayment(ctx, "WG1", &WaitForPaymentOptions{InitialInterval: time.Millisecond})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("WaitForPayment() error = %v, want context.Canceled", err)
+	}
+}
@@ -57,6 +57,9 @@ type CreateProductRequest struct {
 
 // UpdateProductRequest represents a request to update a product
 type UpdateProductRequest struct {
+	// Version is the expected current version; when set, the update fails with a
+	// VersionConflictError if the product has changed since it was read (optional, use Ptr)
+	Version *int `json:"version,omitempty"`
 	// Name is the product name
 	Name string `json:"name" binding:"required,max=100"`
 	// Price is the product price in cents
@@ -126,6 +129,9 @@ func (c *Client) GetProduct(code string) (*Product, error) {
 
 // UpdateProduct updates an existing product
 //
+// When request.Version is set and the product has a different version, a *VersionConflictError
+// is returned; compare with errors.Is(err, ErrVersionConflict) or use ModifyProduct to retry.
+//
 // code: The product code to update
 // request: The product update request containing new product details
 // Returns the updated product information and any error
@@ -134,6 +140,7 @@ func (c *Client) UpdateProduct(code string, request *UpdateProductRequest) (*Pro
 	path := fmt.Sprintf("/app/products/%s", url.PathEscape(code))
 	err := c.requestJSON("PUT", path, request, &result)
 	if err != nil {
+		err = asVersionConflict(err, "product", code, request.Version)
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
 	return &result, nil
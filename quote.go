@@ -0,0 +1,259 @@
+package wordgate
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuoteLineItem represents a priced line of an order quote
+type QuoteLineItem struct {
+	// ItemCode is the product code or membership tier code
+	ItemCode string `json:"item_code"`
+	// ItemName is the product or membership tier name
+	ItemName string `json:"item_name"`
+	// Quantity is the number of items
+	Quantity int `json:"quantity"`
+	// UnitPrice is the unit price in cents
+	UnitPrice int64 `json:"unit_price"`
+	// Subtotal is the total price for this line (UnitPrice * Quantity)
+	Subtotal int64 `json:"subtotal"`
+	// RequireAddress indicates if this item requires shipping address
+	RequireAddress bool `json:"require_address"`
+}
+
+// OrderQuoteResponse represents the price preview of an order that has not been created
+type OrderQuoteResponse struct {
+	// Items is the list of priced lines
+	Items []QuoteLineItem `json:"items"`
+	// Subtotal is the sum of all line subtotals in cents
+	Subtotal int64 `json:"subtotal"`
+	// CouponCode is the applied coupon code (empty if none was applied)
+	CouponCode string `json:"coupon_code"`
+	// CouponRejectedReason explains why the requested coupon was not applied (empty otherwise)
+	CouponRejectedReason string `json:"coupon_rejected_reason,omitempty"`
+	// DiscountAmount is the discount amount in cents
+	DiscountAmount int64 `json:"discount_amount"`
+	// Total is the amount payable in cents (Subtotal - DiscountAmount)
+	Total int64 `json:"total"`
+	// Currency is the currency code
+	Currency string `json:"currency"`
+	// RequireAddress indicates if the order requires shipping address
+	RequireAddress bool `json:"require_address"`
+}
+
+// QuoteAppProductOrder previews the price of a product order without creating it
+//
+// request: The same request that would be passed to CreateAppProductOrder
+// Returns the priced lines, discount and total and any error
+func (c *Client) QuoteAppProductOrder(request *CreateAppProductOrderRequest) (*OrderQuoteResponse, error) {
+	var result OrderQuoteResponse
+	err := c.requestJSON("POST", "/app/product-orders/quote", request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to quote app product order: %w", err)
+	}
+	return &result, nil
+}
+
+// QuoteAppMembershipOrder previews the price of a membership order without creating it
+//
+// request: The same request that would be passed to CreateAppMembershipOrder
+// Returns the priced line, discount and total and any error
+func (c *Client) QuoteAppMembershipOrder(request *CreateAppMembershipOrderRequest) (*OrderQuoteResponse, error) {
+	var result OrderQuoteResponse
+	err := c.requestJSON("POST", "/app/membership-orders/quote", request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to quote app membership order: %w", err)
+	}
+	return &result, nil
+}
+
+// QuoteCalculator computes order quotes locally from cached products and membership tiers
+//
+// It mirrors the totals returned by QuoteAppProductOrder and QuoteAppMembershipOrder for
+// offline previews. Coupon codes are applied from coupons registered with AddCoupons; per-user
+// limits cannot be checked locally, so use the quote API before charging.
+type QuoteCalculator struct {
+	// Currency is the currency code reported in quotes
+	Currency string
+	// Now returns the time coupon validity windows are checked against (defaults to time.Now)
+	Now func() time.Time
+
+	products map[string]Product
+	tiers    map[uint64]MembershipTier
+	coupons  map[string]Coupon
+}
+
+// NewQuoteCalculator creates a calculator from cached catalog data
+//
+// currency: The currency code reported in quotes (e.g., "CNY")
+// products: The products to price product orders with (e.g., from ListProducts)
+// tiers: The membership tiers with prices to price membership orders with (e.g., from ListMembershipTiers)
+func NewQuoteCalculator(currency string, products []Product, tiers []MembershipTier) *QuoteCalculator {
+	q := &QuoteCalculator{
+		Currency: currency,
+		products: make(map[string]Product, len(products)),
+		tiers:    make(map[uint64]MembershipTier, len(tiers)),
+		coupons:  make(map[string]Coupon),
+	}
+	for _, product := range products {
+		q.products[product.Code] = product
+	}
+	for _, tier := range tiers {
+		q.tiers[tier.ID] = tier
+	}
+	return q
+}
+
+// AddCoupons registers coupons used to apply the CouponCode of quoted orders
+//
+// coupons: The coupons to register (e.g., from ListCoupons)
+func (q *QuoteCalculator) AddCoupons(coupons ...Coupon) {
+	for _, coupon := range coupons {
+		q.coupons[coupon.Code] = coupon
+	}
+}
+
+// QuoteProductOrder computes the quote of a product order
+//
+// request: The same request that would be passed to CreateAppProductOrder
+// Returns the priced lines, discount and total, or an error for unknown, inactive or deleted
+// products and unknown or unusable coupons
+func (q *QuoteCalculator) QuoteProductOrder(request *CreateAppProductOrderRequest) (*OrderQuoteResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("order request is required")
+	}
+	if len(request.Items) == 0 {
+		return nil, fmt.Errorf("order has no items")
+	}
+
+	quote := &OrderQuoteResponse{Currency: q.Currency}
+	for _, item := range request.Items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("invalid quantity %d for item %s", item.Quantity, item.ItemCode)
+		}
+
+		product, ok := q.products[item.ItemCode]
+		if !ok {
+			return nil, fmt.Errorf("unknown product: %s", item.ItemCode)
+		}
+		if product.DeletedAt != nil || product.Status != ProductStatusActive {
+			return nil, fmt.Errorf("product is not available: %s", item.ItemCode)
+		}
+
+		line := QuoteLineItem{
+			ItemCode:       product.Code,
+			ItemName:       product.Name,
+			Quantity:       item.Quantity,
+			UnitPrice:      product.Price,
+			Subtotal:       product.Price * int64(item.Quantity),
+			RequireAddress: product.RequireAddress,
+		}
+		quote.Items = append(quote.Items, line)
+		quote.Subtotal += line.Subtotal
+		quote.RequireAddress = quote.RequireAddress || line.RequireAddress
+	}
+
+	if request.CouponCode != "" {
+		coupon, err := q.coupon(request.CouponCode)
+		if err != nil {
+			return nil, err
+		}
+		applies := false
+		var eligible int64
+		for _, line := range quote.Items {
+			if coupon.AppliesToProduct(line.ItemCode) {
+				applies = true
+				eligible += line.Subtotal
+			}
+		}
+		quote.applyCoupon(coupon, applies, eligible)
+	}
+
+	quote.Total = quote.Subtotal - quote.DiscountAmount
+	return quote, nil
+}
+
+// QuoteMembershipOrder computes the quote of a membership order
+//
+// request: The same request that would be passed to CreateAppMembershipOrder
+// Returns the priced line, discount and total, or an error for unknown tiers, periods without
+// a price and unknown or unusable coupons
+func (q *QuoteCalculator) QuoteMembershipOrder(request *CreateAppMembershipOrderRequest) (*OrderQuoteResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("order request is required")
+	}
+	tier, ok := q.tiers[request.TierID]
+	if !ok {
+		return nil, fmt.Errorf("unknown membership tier: %d", request.TierID)
+	}
+	if tier.DeletedAt != nil || tier.Status != MembershipTierStatusActive {
+		return nil, fmt.Errorf("membership tier is not available: %s", tier.Code)
+	}
+
+	periodType := MembershipPeriodType(request.PeriodType)
+	for _, price := range tier.Prices {
+		if price.PeriodType != periodType {
+			continue
+		}
+
+		line := QuoteLineItem{
+			ItemCode:  tier.Code,
+			ItemName:  fmt.Sprintf("%s (%s)", tier.Name, GetPeriodTypeName(periodType)),
+			Quantity:  1,
+			UnitPrice: price.Price,
+			Subtotal:  price.Price,
+		}
+		quote := &OrderQuoteResponse{
+			Items:    []QuoteLineItem{line},
+			Subtotal: line.Subtotal,
+			Currency: q.Currency,
+		}
+		if request.CouponCode != "" {
+			coupon, err := q.coupon(request.CouponCode)
+			if err != nil {
+				return nil, err
+			}
+			quote.applyCoupon(coupon, coupon.AppliesToTier(tier.Code), line.Subtotal)
+		}
+		quote.Total = quote.Subtotal - quote.DiscountAmount
+		return quote, nil
+	}
+
+	return nil, fmt.Errorf("membership tier %s has no price for period %s", tier.Code, request.PeriodType)
+}
+
+// applyCoupon sets the coupon and its discount on the quote, or records why it was rejected
+// when it grants no discount
+func (quote *OrderQuoteResponse) applyCoupon(coupon *Coupon, applies bool, eligible int64) {
+	if !applies {
+		quote.CouponRejectedReason = fmt.Sprintf("coupon %s does not apply to the ordered items", coupon.Code)
+		return
+	}
+	discount := coupon.Discount(eligible)
+	if discount <= 0 {
+		if eligible < coupon.MinOrderAmount {
+			quote.CouponRejectedReason = fmt.Sprintf("coupon %s requires a minimum amount of %d", coupon.Code, coupon.MinOrderAmount)
+		} else {
+			quote.CouponRejectedReason = fmt.Sprintf("coupon %s grants no discount", coupon.Code)
+		}
+		return
+	}
+	quote.CouponCode = coupon.Code
+	quote.DiscountAmount = discount
+}
+
+// coupon looks up a registered coupon and checks it is redeemable now
+func (q *QuoteCalculator) coupon(code string) (*Coupon, error) {
+	coupon, ok := q.coupons[code]
+	if !ok {
+		return nil, fmt.Errorf("unknown coupon: %s", code)
+	}
+	now := time.Now()
+	if q.Now != nil {
+		now = q.Now()
+	}
+	if !coupon.IsRedeemable(now) {
+		return nil, fmt.Errorf("coupon is not redeemable: %s", code)
+	}
+	return &coupon, nil
+}
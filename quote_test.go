@@ -0,0 +1,99 @@
+package wordgate
+
+import (
+	"testing"
+)
+
+func TestQuoteCalculatorCoupons(t *testing.T) {
+	products := []Product{
+		{Code: "BOOK", Name: "Book", Price: 5000, Status: ProductStatusActive},
+		{Code: "PEN", Name: "Pen", Price: 500, Status: ProductStatusActive},
+	}
+	tiers := []MembershipTier{
+		{ID: 1, Code: "PRO", Name: "Pro", Status: MembershipTierStatusActive, Prices: []MembershipPrice{{PeriodType: PeriodTypeMonth, Price: 1900}}},
+	}
+	calculator := NewQuoteCalculator("CNY", products, tiers)
+	calculator.AddCoupons(
+		Coupon{Code: "BOOK10", Type: CouponTypePercentage, Value: 10, ProductCodes: []string{"BOOK"}, Status: CouponStatusActive},
+		Coupon{Code: "MIN100", Type: CouponTypeFixedAmount, Value: 1000, MinOrderAmount: 10000, Status: CouponStatusActive},
+	)
+
+	tests := []struct {
+		name     string
+		quote    func() (*OrderQuoteResponse, error)
+		coupon   string
+		discount int64
+		rejected bool
+	}{
+		{
+			name: "applied to eligible product",
+			quote: func() (*OrderQuoteResponse, error) {
+				return calculator.QuoteProductOrder(&CreateAppProductOrderRequest{
+					Items:      []OrderItem{{ItemCode: "BOOK", Quantity: 1}, {ItemCode: "PEN", Quantity: 2}},
+					CouponCode: "BOOK10",
+				})
+			},
+			coupon:   "BOOK10",
+			discount: 500,
+		},
+		{
+			name: "not applicable to ordered products",
+			quote: func() (*OrderQuoteResponse, error) {
+				return calculator.QuoteProductOrder(&CreateAppProductOrderRequest{
+					Items:      []OrderItem{{ItemCode: "PEN", Quantity: 1}},
+					CouponCode: "BOOK10",
+				})
+			},
+			rejected: true,
+		},
+		{
+			name: "below minimum amount",
+			quote: func() (*OrderQuoteResponse, error) {
+				return calculator.QuoteProductOrder(&CreateAppProductOrderRequest{
+					Items:      []OrderItem{{ItemCode: "BOOK", Quantity: 1}},
+					CouponCode: "MIN100",
+				})
+			},
+			rejected: true,
+		},
+		{
+			name: "not applicable to membership tier",
+			quote: func() (*OrderQuoteResponse, error) {
+				return calculator.QuoteMembershipOrder(&CreateAppMembershipOrderRequest{
+					TierID:     1,
+					PeriodType: string(PeriodTypeMonth),
+					CouponCode: "BOOK10",
+				})
+			},
+			rejected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := tt.quote()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if quote.CouponCode != tt.coupon || quote.DiscountAmount != tt.discount {
+				t.Errorf("coupon = %q, discount = %d; want %q, %d", quote.CouponCode, quote.DiscountAmount, tt.coupon, tt.discount)
+			}
+			if (quote.CouponRejectedReason != "") != tt.rejected {
+				t.Errorf("CouponRejectedReason = %q, want rejected = %v", quote.CouponRejectedReason, tt.rejected)
+			}
+			if quote.Total != quote.Subtotal-quote.DiscountAmount {
+				t.Errorf("Total = %d, want %d", quote.Total, quote.Subtotal-quote.DiscountAmount)
+			}
+		})
+	}
+}
+
+func TestQuoteCalculatorRejectsNilRequests(t *testing.T) {
+	calculator := NewQuoteCalculator("CNY", nil, nil)
+	if _, err := calculator.QuoteProductOrder(nil); err == nil {
+		t.Error("QuoteProductOrder(nil) succeeded")
+	}
+	if _, err := calculator.QuoteMembershipOrder(nil); err == nil {
+		t.Error("QuoteMembershipOrder(nil) succeeded")
+	}
+}
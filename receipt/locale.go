@@ -0,0 +1,181 @@
+package receipt
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale controls how money, dates and labels are rendered
+type Locale struct {
+	// Code is the locale code (e.g., "en-US")
+	Code string
+	// DateFormat is the Go time layout for dates
+	DateFormat string
+	// DecimalSeparator separates the integer and fractional parts of amounts
+	DecimalSeparator string
+	// ThousandsSeparator groups the integer digits of amounts
+	ThousandsSeparator string
+	// SymbolAfter places the currency symbol after the amount
+	SymbolAfter bool
+	// Symbols maps currency codes to symbols; unknown currencies are rendered by code
+	Symbols map[string]string
+	// Labels are the translated template labels by key
+	Labels map[string]string
+}
+
+var (
+	// LocaleEnUS formats receipts in US English
+	LocaleEnUS = Locale{
+		Code:               "en-US",
+		DateFormat:         "Jan 2, 2006",
+		DecimalSeparator:   ".",
+		ThousandsSeparator: ",",
+		Symbols:            map[string]string{"USD": "$", "EUR": "€", "GBP": "£", "CNY": "CN¥", "JPY": "¥"},
+		Labels: map[string]string{
+			"receipt":   "Receipt",
+			"invoice":   "Invoice No.",
+			"order":     "Order No.",
+			"date":      "Date",
+			"item":      "Item",
+			"quantity":  "Qty",
+			"price":     "Unit Price",
+			"amount":    "Amount",
+			"subtotal":  "Subtotal",
+			"discount":  "Discount",
+			"coupon":    "Coupon",
+			"total":     "Total",
+			"ship_to":   "Ship To",
+			"paid_with": "Paid With",
+		},
+	}
+
+	// LocaleZhCN formats receipts in Simplified Chinese
+	LocaleZhCN = Locale{
+		Code:               "zh-CN",
+		DateFormat:         "2006年1月2日",
+		DecimalSeparator:   ".",
+		ThousandsSeparator: ",",
+		Symbols:            map[string]string{"CNY": "¥", "USD": "US$", "EUR": "€", "GBP": "£", "JPY": "JP¥"},
+		Labels: map[string]string{
+			"receipt":   "收据",
+			"invoice":   "发票号",
+			"order":     "订单号",
+			"date":      "日期",
+			"item":      "商品",
+			"quantity":  "数量",
+			"price":     "单价",
+			"amount":    "金额",
+			"subtotal":  "小计",
+			"discount":  "优惠",
+			"coupon":    "优惠券",
+			"total":     "合计",
+			"ship_to":   "收货地址",
+			"paid_with": "支付方式",
+		},
+	}
+
+	// LocaleDeDE formats receipts in German
+	LocaleDeDE = Locale{
+		Code:               "de-DE",
+		DateFormat:         "02.01.2006",
+		DecimalSeparator:   ",",
+		ThousandsSeparator: ".",
+		SymbolAfter:        true,
+		Symbols:            map[string]string{"EUR": "€", "USD": "$", "GBP": "£", "CNY": "CN¥", "JPY": "¥"},
+		Labels: map[string]string{
+			"receipt":   "Quittung",
+			"invoice":   "Rechnungsnr.",
+			"order":     "Bestellnr.",
+			"date":      "Datum",
+			"item":      "Artikel",
+			"quantity":  "Menge",
+			"price":     "Einzelpreis",
+			"amount":    "Betrag",
+			"subtotal":  "Zwischensumme",
+			"discount":  "Rabatt",
+			"coupon":    "Gutschein",
+			"total":     "Gesamt",
+			"ship_to":   "Lieferadresse",
+			"paid_with": "Bezahlt mit",
+		},
+	}
+)
+
+// currencyDecimals are the minor unit digits of currencies that do not use two
+var currencyDecimals = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+	"BHD": 3,
+	"KWD": 3,
+}
+
+// FormatMoney formats an amount in minor units (e.g., cents) with the currency symbol
+//
+// amount: The amount in the currency's minor unit
+// currency: The currency code (e.g., "USD")
+func (l Locale) FormatMoney(amount int64, currency string) string {
+	decimals, ok := currencyDecimals[strings.ToUpper(currency)]
+	if !ok {
+		decimals = 2
+	}
+
+	negative := amount < 0
+	if negative {
+		amount = -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	integer, fraction := digits[:len(digits)-decimals], digits[len(digits)-decimals:]
+
+	// Group the integer digits
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteString(l.ThousandsSeparator)
+		}
+		grouped.WriteRune(digit)
+	}
+	number := grouped.String()
+	if decimals > 0 {
+		number += l.DecimalSeparator + fraction
+	}
+
+	symbol, ok := l.Symbols[strings.ToUpper(currency)]
+	var result string
+	switch {
+	case !ok:
+		result = number + " " + strings.ToUpper(currency)
+	case l.SymbolAfter:
+		result = number + " " + symbol
+	default:
+		result = symbol + number
+	}
+	if negative {
+		result = "-" + result
+	}
+	return result
+}
+
+// FormatDate formats a date with the locale's layout
+func (l Locale) FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	layout := l.DateFormat
+	if layout == "" {
+		layout = "2006-01-02"
+	}
+	return t.Format(layout)
+}
+
+// Label returns the translated label for a key, or the key itself if it has no translation
+func (l Locale) Label(key string) string {
+	if label, ok := l.Labels[key]; ok {
+		return label
+	}
+	return key
+}
@@ -0,0 +1,68 @@
+package receipt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocaleFormatMoney(t *testing.T) {
+	tests := []struct {
+		locale   Locale
+		amount   int64
+		currency string
+		want     string
+	}{
+		{LocaleEnUS, 123456789, "USD", "$1,234,567.89"},
+		{LocaleEnUS, 0, "USD", "$0.00"},
+		{LocaleEnUS, 5, "USD", "$0.05"},
+		{LocaleEnUS, -1050, "USD", "-$10.50"},
+		{LocaleEnUS, 100000, "usd", "$1,000.00"},
+		// Zero-decimal currency
+		{LocaleEnUS, 1234567, "JPY", "¥1,234,567"},
+		{LocaleEnUS, 0, "JPY", "¥0"},
+		{LocaleEnUS, -500, "JPY", "-¥500"},
+		// Three-decimal currencies without a symbol are rendered by code
+		{LocaleEnUS, 1234, "KWD", "1.234 KWD"},
+		{LocaleEnUS, 5, "BHD", "0.005 BHD"},
+		{LocaleEnUS, 1999, "CHF", "19.99 CHF"},
+		{LocaleZhCN, 990, "cny", "¥9.90"},
+		{LocaleZhCN, 990, "USD", "US$9.90"},
+		// Symbol after the amount with swapped separators
+		{LocaleDeDE, 123456, "EUR", "1.234,56 €"},
+		{LocaleDeDE, -99, "EUR", "-0,99 €"},
+		{LocaleDeDE, 1234567, "JPY", "1.234.567 ¥"},
+		{LocaleDeDE, 1000, "xyz", "10,00 XYZ"},
+	}
+	for _, tt := range tests {
+		if got := tt.locale.FormatMoney(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("%s FormatMoney(%d, %s) = %q, want %q", tt.locale.Code, tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestLocaleFormatDate(t *testing.T) {
+	date := time.Date(2024, 3, 5, 18, 30, 0, 0, time.UTC)
+	tests := []struct {
+		locale Locale
+		want   string
+	}{
+		{LocaleEnUS, "Mar 5, 2024"},
+		{LocaleZhCN, "2024年3月5日"},
+		{LocaleDeDE, "05.03.2024"},
+		{Locale{}, "2024-03-05"},
+	}
+	for _, tt := range tests {
+		if got := tt.locale.FormatDate(date); got != tt.want {
+			t.Errorf("%q FormatDate() = %q, want %q", tt.locale.Code, got, tt.want)
+		}
+	}
+	if got := LocaleEnUS.FormatDate(time.Time{}); got != "" {
+		t.Errorf("FormatDate(zero) = %q, want empty", got)
+	}
+	if got := LocaleDeDE.Label("total"); got != "Gesamt" {
+		t.Errorf("Label(total) = %q, want Gesamt", got)
+	}
+	if got := LocaleDeDE.Label("unknown"); got != "unknown" {
+		t.Errorf("Label(unknown) = %q, want the key", got)
+	}
+}
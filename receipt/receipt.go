@@ -0,0 +1,339 @@
+/*
+Package receipt renders HTML and plain-text receipts for paid WordGate orders.
+
+A Renderer turns an order from GetAppOrder into a Receipt with an invoice number from an
+InvoiceSequence, issued once per order and remembered in an InvoiceStore, then executes
+html/template and text/template templates against it. The templates can be replaced and use
+the money, date and label functions, which format amounts, dates and labels for the
+renderer's Locale.
+
+Usage example:
+
+	renderer := receipt.NewRenderer(receipt.LocaleZhCN, receipt.NewYearlySequence("INV-"))
+
+	order, err := client.GetAppOrder("ORDER123")
+	if err != nil {
+		log.Fatal(err)
+	}
+	r, err := renderer.Build(order)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var html bytes.Buffer
+	renderer.RenderHTML(&html, r)
+	renderer.RenderText(os.Stdout, r)
+
+	// Custom template
+	err = renderer.ParseHTML(`<h1>{{label "receipt"}} {{.InvoiceNumber}}</h1><p>{{money .Total .Currency}}</p>`)
+*/
+package receipt
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// ErrOrderNotPaid indicates a receipt was requested for an unpaid order
+var ErrOrderNotPaid = errors.New("order is not paid")
+
+// Line is a receipt line
+type Line struct {
+	// Name is the item name
+	Name string
+	// Quantity is the number of items
+	Quantity int
+	// UnitPrice is the unit price in minor units
+	UnitPrice int64
+	// Amount is the line total in minor units
+	Amount int64
+}
+
+// Receipt is the data receipt templates are rendered from
+type Receipt struct {
+	// InvoiceNumber is the number issued by the invoice sequence
+	InvoiceNumber string
+	// IssuedAt is when the receipt was issued
+	IssuedAt time.Time
+	// OrderNo is the order number
+	OrderNo string
+	// PaidAt is the payment time
+	PaidAt time.Time
+	// Lines are the order items
+	Lines []Line
+	// Subtotal is the sum of the lines in minor units
+	Subtotal int64
+	// CouponCode is the applied coupon code (empty if none)
+	CouponCode string
+	// Discount is the discount in minor units
+	Discount int64
+	// Total is the paid amount in minor units
+	Total int64
+	// Currency is the currency code
+	Currency string
+	// PaymentProvider is the provider of the successful payment (empty if unknown)
+	PaymentProvider string
+	// Address is the shipping address (nil if the order has none)
+	Address *wordgate.AddressInfo
+	// Order is the source order details
+	Order *wordgate.OrderDetailResponse
+}
+
+// Renderer builds and renders receipts
+type Renderer struct {
+	// Locale controls money, date and label formatting
+	Locale Locale
+	// Sequence issues invoice numbers
+	Sequence InvoiceSequence
+	// Invoices remembers the invoice issued for each order (nil issues a new number on every Build)
+	Invoices InvoiceStore
+	// Now returns the issue time of receipts (defaults to time.Now)
+	Now func() time.Time
+
+	mu   sync.Mutex
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// NewRenderer creates a renderer with the default templates
+//
+// locale: Controls money, date and label formatting
+// sequence: Issues invoice numbers
+func NewRenderer(locale Locale, sequence InvoiceSequence) *Renderer {
+	r := &Renderer{
+		Locale:   locale,
+		Sequence: sequence,
+		Invoices: NewMemoryInvoiceStore(),
+	}
+	// The default templates are known to parse
+	_ = r.ParseHTML(DefaultHTMLTemplate)
+	_ = r.ParseText(DefaultTextTemplate)
+	return r
+}
+
+// Funcs returns the template functions bound to the renderer's locale:
+// money(amount, currency), date(time) and label(key)
+func (r *Renderer) Funcs() map[string]any {
+	return map[string]any{
+		"money": func(amount int64, currency string) string { return r.Locale.FormatMoney(amount, currency) },
+		"date":  func(t time.Time) string { return r.Locale.FormatDate(t) },
+		"label": func(key string) string { return r.Locale.Label(key) },
+	}
+}
+
+// ParseHTML replaces the HTML template
+//
+// text: An html/template source rendered with a *Receipt
+func (r *Renderer) ParseHTML(text string) error {
+	tmpl, err := htmltemplate.New("receipt.html").Funcs(r.Funcs()).Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse HTML receipt template: %w", err)
+	}
+	r.html = tmpl
+	return nil
+}
+
+// ParseText replaces the plain-text template
+//
+// text: A text/template source rendered with a *Receipt
+func (r *Renderer) ParseText(text string) error {
+	tmpl, err := texttemplate.New("receipt.txt").Funcs(r.Funcs()).Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse text receipt template: %w", err)
+	}
+	r.text = tmpl
+	return nil
+}
+
+// Build creates a receipt for a paid order
+//
+// The invoice number is issued on the first Build of an order and reused afterwards.
+//
+// order: The order details from GetAppOrder
+// Returns the receipt, or ErrOrderNotPaid for unpaid orders
+func (r *Renderer) Build(order *wordgate.OrderDetailResponse) (*Receipt, error) {
+	if !order.IsPaid {
+		return nil, fmt.Errorf("failed to build receipt for order %s: %w", order.OrderNo, ErrOrderNotPaid)
+	}
+
+	receipt := &Receipt{
+		OrderNo:    order.OrderNo,
+		CouponCode: order.CouponCode,
+		Discount:   order.DiscountAmount,
+		Total:      order.Amount,
+		Currency:   order.Currency,
+		Address:    order.Address,
+		Order:      order,
+	}
+	if order.PaidAt != nil {
+		paidAt, err := time.Parse(time.RFC3339, *order.PaidAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse paid time of order %s: %w", order.OrderNo, err)
+		}
+		receipt.PaidAt = paidAt
+	}
+	for _, item := range order.Items {
+		receipt.Lines = append(receipt.Lines, Line{
+			Name:      item.ItemName,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Amount:    item.Subtotal,
+		})
+		receipt.Subtotal += item.Subtotal
+	}
+	for _, intent := range order.PaymentIntents {
+		if intent.PaidAt != nil {
+			receipt.PaymentProvider = intent.Provider
+			break
+		}
+	}
+
+	invoice, err := r.invoice(order.OrderNo)
+	if err != nil {
+		return nil, err
+	}
+	receipt.InvoiceNumber = invoice.Number
+	receipt.IssuedAt = invoice.IssuedAt
+	return receipt, nil
+}
+
+// invoice returns the invoice of an order, issuing and saving a new one on first use
+func (r *Renderer) invoice(orderNo string) (Invoice, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Invoices != nil {
+		invoice, ok, err := r.Invoices.Lookup(orderNo)
+		if err != nil {
+			return Invoice{}, fmt.Errorf("failed to look up invoice of order %s: %w", orderNo, err)
+		}
+		if ok {
+			return invoice, nil
+		}
+	}
+
+	invoice := Invoice{IssuedAt: time.Now()}
+	if r.Now != nil {
+		invoice.IssuedAt = r.Now()
+	}
+	if r.Sequence == nil {
+		return invoice, nil
+	}
+	number, err := r.Sequence.Next(invoice.IssuedAt)
+	if err != nil {
+		return Invoice{}, fmt.Errorf("failed to issue invoice number: %w", err)
+	}
+	invoice.Number = number
+	if r.Invoices != nil {
+		if err := r.Invoices.Save(orderNo, invoice); err != nil {
+			return Invoice{}, fmt.Errorf("failed to save invoice of order %s: %w", orderNo, err)
+		}
+	}
+	return invoice, nil
+}
+
+// RenderHTML renders a receipt with the HTML template
+func (r *Renderer) RenderHTML(w io.Writer, receipt *Receipt) error {
+	if err := r.html.Execute(w, receipt); err != nil {
+		return fmt.Errorf("failed to render HTML receipt: %w", err)
+	}
+	return nil
+}
+
+// RenderText renders a receipt with the plain-text template
+func (r *Renderer) RenderText(w io.Writer, receipt *Receipt) error {
+	if err := r.text.Execute(w, receipt); err != nil {
+		return fmt.Errorf("failed to render text receipt: %w", err)
+	}
+	return nil
+}
+
+// AddressLines returns the address as display lines (empty for a nil address)
+func (r *Receipt) AddressLines() []string {
+	if r.Address == nil {
+		return nil
+	}
+	a := r.Address
+	var lines []string
+	for _, line := range []string{
+		strings.TrimSpace(a.Name + " " + a.Phone),
+		a.Street,
+		strings.Join(nonEmpty(a.District, a.City, a.Province, a.PostalCode), ", "),
+	} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// nonEmpty returns the non-empty values
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// DefaultHTMLTemplate is the default html/template source for receipts
+const DefaultHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{label "receipt"}} {{.InvoiceNumber}}</title>
+<style>
+body { font-family: sans-serif; color: #222; max-width: 640px; margin: 2em auto; }
+table { width: 100%; border-collapse: collapse; }
+th, td { padding: 6px 4px; border-bottom: 1px solid #ddd; text-align: left; }
+.num { text-align: right; }
+.total td { font-weight: bold; border-bottom: none; }
+</style>
+</head>
+<body>
+<h1>{{label "receipt"}}</h1>
+<p>
+{{if .InvoiceNumber}}{{label "invoice"}}: {{.InvoiceNumber}}<br>{{end}}
+{{label "order"}}: {{.OrderNo}}<br>
+{{label "date"}}: {{date .PaidAt}}
+</p>
+<table>
+<tr><th>{{label "item"}}</th><th class="num">{{label "quantity"}}</th><th class="num">{{label "price"}}</th><th class="num">{{label "amount"}}</th></tr>
+{{range .Lines}}<tr><td>{{.Name}}</td><td class="num">{{.Quantity}}</td><td class="num">{{money .UnitPrice $.Currency}}</td><td class="num">{{money .Amount $.Currency}}</td></tr>
+{{end}}<tr><td colspan="3" class="num">{{label "subtotal"}}</td><td class="num">{{money .Subtotal .Currency}}</td></tr>
+{{if .Discount}}<tr><td colspan="3" class="num">{{label "discount"}}{{if .CouponCode}} ({{.CouponCode}}){{end}}</td><td class="num">-{{money .Discount .Currency}}</td></tr>
+{{end}}<tr class="total"><td colspan="3" class="num">{{label "total"}}</td><td class="num">{{money .Total .Currency}}</td></tr>
+</table>
+{{if .PaymentProvider}}<p>{{label "paid_with"}}: {{.PaymentProvider}}</p>{{end}}
+{{with .AddressLines}}<h2>{{label "ship_to"}}</h2>
+<p>{{range $i, $line := .}}{{if $i}}<br>{{end}}{{$line}}{{end}}</p>{{end}}
+</body>
+</html>
+`
+
+// DefaultTextTemplate is the default text/template source for receipts
+const DefaultTextTemplate = `{{label "receipt"}}
+{{if .InvoiceNumber}}{{label "invoice"}}: {{.InvoiceNumber}}
+{{end}}{{label "order"}}: {{.OrderNo}}
+{{label "date"}}: {{date .PaidAt}}
+
+{{range .Lines}}{{.Name}} x{{.Quantity}} @ {{money .UnitPrice $.Currency}} = {{money .Amount $.Currency}}
+{{end}}
+{{label "subtotal"}}: {{money .Subtotal .Currency}}
+{{if .Discount}}{{label "discount"}}{{if .CouponCode}} ({{.CouponCode}}){{end}}: -{{money .Discount .Currency}}
+{{end}}{{label "total"}}: {{money .Total .Currency}}
+{{if .PaymentProvider}}{{label "paid_with"}}: {{.PaymentProvider}}
+{{end}}{{with .AddressLines}}
+{{label "ship_to"}}:
+{{range .}}{{.}}
+{{end}}{{end}}`
@@ -0,0 +1,147 @@
+package receipt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+func TestRendererReusesInvoiceNumberPerOrder(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	renderer := NewRenderer(LocaleEnUS, NewYearlySequence("INV-"))
+	renderer.Now = func() time.Time { return now }
+
+	paidAt := now.Format(time.RFC3339)
+	first := &wordgate.OrderDetailResponse{OrderNo: "WG1", IsPaid: true, PaidAt: &paidAt}
+	second := &wordgate.OrderDetailResponse{OrderNo: "WG2", IsPaid: true, PaidAt: &paidAt}
+
+	a, err := renderer.Build(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Hour)
+	again, err := renderer.Build(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := renderer.Build(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.InvoiceNumber != "INV-2024-000001" || again.InvoiceNumber != a.InvoiceNumber {
+		t.Fatalf("invoice numbers for WG1 = %s, %s; want INV-2024-000001 twice", a.InvoiceNumber, again.InvoiceNumber)
+	}
+	if !again.IssuedAt.Equal(a.IssuedAt) {
+		t.Fatalf("rebuilt receipt issued at %v, want %v", again.IssuedAt, a.IssuedAt)
+	}
+	if b.InvoiceNumber != "INV-2024-000002" {
+		t.Fatalf("invoice number for WG2 = %s, want INV-2024-000002", b.InvoiceNumber)
+	}
+}
+
+func TestRendererRejectsUnpaidOrders(t *testing.T) {
+	renderer := NewRenderer(LocaleEnUS, NewYearlySequence("INV-"))
+	_, err := renderer.Build(&wordgate.OrderDetailResponse{OrderNo: "WG1"})
+	if !errors.Is(err, ErrOrderNotPaid) {
+		t.Fatalf("Build() error = %v, want ErrOrderNotPaid", err)
+	}
+	if last := renderer.Sequence.(*YearlySequence).Last(time.Now().Year()); last != 0 {
+		t.Fatalf("unpaid order consumed invoice number %d", last)
+	}
+}
+
+func testOrder() *wordgate.OrderDetailResponse {
+	paidAt := "2024-03-05T10:00:00Z"
+	return &wordgate.OrderDetailResponse{
+		OrderNo:        "WG1",
+		IsPaid:         true,
+		PaidAt:         &paidAt,
+		Amount:         8325,
+		Currency:       "EUR",
+		CouponCode:     "SAVE10",
+		DiscountAmount: 925,
+		Items: []wordgate.OrderItemInfo{
+			{ItemName: "Kurs", Quantity: 2, UnitPrice: 4500, Subtotal: 9000},
+			{ItemName: "Stift & Block", Quantity: 1, UnitPrice: 250, Subtotal: 250},
+		},
+		PaymentIntents: []wordgate.PaymentIntentInfo{
+			{Provider: "stripe"},
+			{Provider: "paypal", PaidAt: &paidAt},
+		},
+		Address: &wordgate.AddressInfo{Name: "Max", Phone: "0151", Street: "Hauptstr. 1", City: "Berlin", PostalCode: "10115"},
+	}
+}
+
+func TestRendererDefaultTemplates(t *testing.T) {
+	renderer := NewRenderer(LocaleDeDE, NewYearlySequence("INV-"))
+	renderer.Now = func() time.Time { return time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC) }
+	receipt, err := renderer.Build(testOrder())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var text strings.Builder
+	if err := renderer.RenderText(&text, receipt); err != nil {
+		t.Fatal(err)
+	}
+	want := `Quittung
+Rechnungsnr.: INV-2024-000001
+Bestellnr.: WG1
+Datum: 05.03.2024
+
+Kurs x2 @ 45,00 € = 90,00 €
+Stift & Block x1 @ 2,50 € = 2,50 €
+
+Zwischensumme: 92,50 €
+Rabatt (SAVE10): -9,25 €
+Gesamt: 83,25 €
+Bezahlt mit: paypal
+
+Lieferadresse:
+Max 0151
+Hauptstr. 1
+Berlin, 10115
+`
+	if text.String() != want {
+		t.Fatalf("RenderText() =\n%s\nwant\n%s", text.String(), want)
+	}
+
+	var html strings.Builder
+	if err := renderer.RenderHTML(&html, receipt); err != nil {
+		t.Fatal(err)
+	}
+	for _, fragment := range []string{
+		"<title>Quittung INV-2024-000001</title>",
+		"Datum: 05.03.2024",
+		"<td>Stift &amp; Block</td>",
+		`<td class="num">90,00 €</td>`,
+		"Rabatt (SAVE10)</td><td class=\"num\">-9,25 €</td>",
+		"Bezahlt mit: paypal",
+		"Max 0151<br>Hauptstr. 1<br>Berlin, 10115",
+	} {
+		if !strings.Contains(html.String(), fragment) {
+			t.Errorf("RenderHTML() is missing %q:\n%s", fragment, html.String())
+		}
+	}
+
+	// Optional sections are left out
+	order := testOrder()
+	order.OrderNo, order.CouponCode, order.DiscountAmount, order.PaymentIntents, order.Address = "WG2", "", 0, nil, nil
+	receipt, err = renderer.Build(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html.Reset()
+	if err := renderer.RenderHTML(&html, receipt); err != nil {
+		t.Fatal(err)
+	}
+	for _, label := range []string{"Rabatt", "Bezahlt mit", "Lieferadresse"} {
+		if strings.Contains(html.String(), label) {
+			t.Errorf("RenderHTML() without %s still contains it", label)
+		}
+	}
+}
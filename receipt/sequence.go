@@ -0,0 +1,117 @@
+package receipt
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// InvoiceSequence issues invoice numbers
+type InvoiceSequence interface {
+	// Next returns the next invoice number for an invoice issued at the given time
+	Next(issuedAt time.Time) (string, error)
+}
+
+// YearlySequence issues invoice numbers like "INV-2024-000001", restarting every year
+type YearlySequence struct {
+	// Prefix is prepended to every number (e.g., "INV-")
+	Prefix string
+	// Width is the zero-padded width of the counter (defaults to 6)
+	Width int
+
+	mu   sync.Mutex
+	last map[int]int
+}
+
+// NewYearlySequence creates a yearly invoice number sequence
+//
+// prefix: Prepended to every number (e.g., "INV-")
+func NewYearlySequence(prefix string) *YearlySequence {
+	return &YearlySequence{
+		Prefix: prefix,
+		Width:  6,
+		last:   make(map[int]int),
+	}
+}
+
+// Restore sets the last number issued in a year, so that a sequence persisted
+// elsewhere continues without reusing numbers
+//
+// year: The year of the counter
+// last: The last number issued in that year
+func (s *YearlySequence) Restore(year, last int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.last == nil {
+		s.last = make(map[int]int)
+	}
+	s.last[year] = last
+}
+
+// Last returns the last number issued in a year, for persisting the sequence
+func (s *YearlySequence) Last(year int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last[year]
+}
+
+// Next returns the next invoice number for the year of issuedAt
+func (s *YearlySequence) Next(issuedAt time.Time) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.last == nil {
+		s.last = make(map[int]int)
+	}
+
+	width := s.Width
+	if width <= 0 {
+		width = 6
+	}
+	year := issuedAt.Year()
+	s.last[year]++
+	return fmt.Sprintf("%s%d-%0*d", s.Prefix, year, width, s.last[year]), nil
+}
+
+// Invoice is an invoice number issued for an order
+type Invoice struct {
+	// Number is the invoice number
+	Number string
+	// IssuedAt is when the number was issued
+	IssuedAt time.Time
+}
+
+// InvoiceStore remembers the invoice issued for each order, so that rebuilding the
+// receipt of an order reuses its invoice number instead of issuing a new one
+type InvoiceStore interface {
+	// Lookup returns the invoice issued for an order and whether one was found
+	Lookup(orderNo string) (Invoice, bool, error)
+	// Save records the invoice issued for an order
+	Save(orderNo string, invoice Invoice) error
+}
+
+// MemoryInvoiceStore is an in-memory InvoiceStore
+type MemoryInvoiceStore struct {
+	mu       sync.RWMutex
+	invoices map[string]Invoice
+}
+
+// NewMemoryInvoiceStore creates an empty in-memory invoice store
+func NewMemoryInvoiceStore() *MemoryInvoiceStore {
+	return &MemoryInvoiceStore{invoices: make(map[string]Invoice)}
+}
+
+// Lookup returns the invoice issued for an order and whether one was found
+func (s *MemoryInvoiceStore) Lookup(orderNo string) (Invoice, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	invoice, ok := s.invoices[orderNo]
+	return invoice, ok, nil
+}
+
+// Save records the invoice issued for an order
+func (s *MemoryInvoiceStore) Save(orderNo string, invoice Invoice) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.invoices[orderNo] = invoice
+	return nil
+}
@@ -0,0 +1,314 @@
+/*
+Reconciliation between order.paid webhooks and the orders API.
+
+Webhooks can be lost, so a Reconciler periodically pages through ListAppOrders for a time
+window and compares the paid orders against a ProcessedOrderStore of order.paid events the
+application has handled. Orders paid according to the API but never processed are reported
+and can be re-emitted as synthetic order.paid events; processed orders the API reports as
+unpaid (or cannot find) are reported as unexpected.
+
+Usage example:
+
+	store := wordgate.NewMemoryProcessedOrderStore()
+
+	// Record processed order.paid events while handling webhooks
+	http.Handle("/webhook", wordgate.NewWebhookHandler("your_webhook_secret", wordgate.TrackProcessedOrders(store, process)))
+
+	reconciler := wordgate.NewReconciler(client, store)
+	reconciler.OnMissing = process // feed missed payments back into the same pipeline
+	reconciler.OnReport = func(report *wordgate.ReconcileReport) {
+		for _, data := range report.Unexpected {
+			log.Printf("Order %s processed but not paid", data.WordgateOrderNo)
+		}
+	}
+
+	go reconciler.Run(ctx)
+*/
+package wordgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ProcessedOrderStore records order.paid events that have been processed
+type ProcessedOrderStore interface {
+	// MarkProcessed records an order.paid event as processed
+	MarkProcessed(data WebhookOrderPaidData) error
+	// IsProcessed reports whether an order.paid event was processed for the order
+	IsProcessed(orderNo string) (bool, error)
+	// ListProcessed returns processed events paid within [start, end)
+	ListProcessed(start, end time.Time) ([]WebhookOrderPaidData, error)
+}
+
+// MemoryProcessedOrderStore is an in-memory ProcessedOrderStore
+type MemoryProcessedOrderStore struct {
+	mu     sync.RWMutex
+	orders map[string]WebhookOrderPaidData
+}
+
+// NewMemoryProcessedOrderStore creates an empty in-memory processed order store
+func NewMemoryProcessedOrderStore() *MemoryProcessedOrderStore {
+	return &MemoryProcessedOrderStore{
+		orders: make(map[string]WebhookOrderPaidData),
+	}
+}
+
+// MarkProcessed records an order.paid event as processed
+func (s *MemoryProcessedOrderStore) MarkProcessed(data WebhookOrderPaidData) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[data.WordgateOrderNo] = data
+	return nil
+}
+
+// IsProcessed reports whether an order.paid event was processed for the order
+func (s *MemoryProcessedOrderStore) IsProcessed(orderNo string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.orders[orderNo]
+	return ok, nil
+}
+
+// ListProcessed returns processed events paid within [start, end), ordered by payment time
+func (s *MemoryProcessedOrderStore) ListProcessed(start, end time.Time) ([]WebhookOrderPaidData, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []WebhookOrderPaidData
+	for _, data := range s.orders {
+		if data.PaidAt != nil && !data.PaidAt.Before(start) && data.PaidAt.Before(end) {
+			result = append(result, data)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PaidAt.Before(*result[j].PaidAt)
+	})
+	return result, nil
+}
+
+// TrackProcessedOrders wraps a webhook process function and records order.paid events
+// in the store once they have been processed successfully
+//
+// store: The store to record processed events in
+// next: The process function handling the events
+// Returns the wrapping process function
+func TrackProcessedOrders(store ProcessedOrderStore, next WebhookProcessFunc) WebhookProcessFunc {
+	return func(ctx context.Context, event *WebhookEventData) error {
+		if next != nil {
+			if err := next(ctx, event); err != nil {
+				return err
+			}
+		}
+		if event.EventType != WebhookEventOrderPaid {
+			return nil
+		}
+
+		var data WebhookOrderPaidData
+		if err := event.Parse(&data); err != nil {
+			return fmt.Errorf("failed to parse order paid data: %w", err)
+		}
+		if err := store.MarkProcessed(data); err != nil {
+			return fmt.Errorf("failed to mark order as processed: %w", err)
+		}
+		return nil
+	}
+}
+
+// ReconcileReport describes the discrepancies found for a time window
+type ReconcileReport struct {
+	// Start is the beginning of the reconciled window (inclusive)
+	Start time.Time
+	// End is the end of the reconciled window (exclusive)
+	End time.Time
+	// Checked is the number of paid orders returned by the API within the window
+	Checked int
+	// Missing are orders paid according to the API but never processed
+	Missing []OrderListItem
+	// Unexpected are processed events for orders the API reports as unpaid or unknown
+	Unexpected []WebhookOrderPaidData
+	// Emitted is the number of synthetic order.paid events successfully handled by OnMissing
+	Emitted int
+	// EmitErrors contains the errors returned by OnMissing
+	EmitErrors []error
+}
+
+// HasDiscrepancies reports whether any missing or unexpected orders were found
+func (r *ReconcileReport) HasDiscrepancies() bool {
+	return len(r.Missing) > 0 || len(r.Unexpected) > 0
+}
+
+// Reconciler compares paid orders from the orders API with processed order.paid events
+type Reconciler struct {
+	// Client is the WordGate API client
+	Client *Client
+	// Store is the store of processed order.paid events
+	Store ProcessedOrderStore
+	// AppID is set on synthetic events
+	AppID uint64
+	// PageSize is the number of orders fetched per page
+	PageSize int
+	// Window is how far back each periodic run looks
+	Window time.Duration
+	// Interval is the time between periodic runs
+	Interval time.Duration
+	// CreatedLookback widens the order creation date filter so that orders created
+	// before the window but paid within it are included
+	CreatedLookback time.Duration
+	// OnMissing receives a synthetic order.paid event for every missing order (optional)
+	OnMissing WebhookProcessFunc
+	// OnReport receives the report of every periodic run (optional)
+	OnReport func(report *ReconcileReport)
+	// OnError receives errors of periodic runs (optional)
+	OnError func(err error)
+}
+
+// NewReconciler creates a reconciler with default settings: hourly runs over the last 24 hours
+//
+// client: The WordGate API client
+// store: The store of processed order.paid events
+func NewReconciler(client *Client, store ProcessedOrderStore) *Reconciler {
+	return &Reconciler{
+		Client:          client,
+		Store:           store,
+		PageSize:        100,
+		Window:          24 * time.Hour,
+		Interval:        time.Hour,
+		CreatedLookback: 7 * 24 * time.Hour,
+	}
+}
+
+// Reconcile compares the orders paid within [start, end) with the processed events
+//
+// ctx: Context for cancellation, also passed to OnMissing
+// start: Beginning of the window (inclusive)
+// end: End of the window (exclusive)
+// Returns the discrepancy report and any error
+func (r *Reconciler) Reconcile(ctx context.Context, start, end time.Time) (*ReconcileReport, error) {
+	report := &ReconcileReport{Start: start, End: end}
+
+	// Collect paid orders within the window from the API
+	paid := make(map[string]bool)
+	iterator := r.Client.IterateAppOrders(&ListOrdersQuery{
+		Limit:   r.PageSize,
+		Status:  "paid",
+		StartAt: start.Add(-r.CreatedLookback).Format("2006-01-02"),
+		EndAt:   end.AddDate(0, 0, 1).Format("2006-01-02"),
+	})
+	for iterator.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		order := iterator.Order()
+		if !order.IsPaid || order.PaidAt == nil || order.PaidAt.Before(start) || !order.PaidAt.Before(end) {
+			continue
+		}
+		report.Checked++
+		paid[order.OrderNo] = true
+
+		processed, err := r.Store.IsProcessed(order.OrderNo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check processed order: %w", err)
+		}
+		if !processed {
+			report.Missing = append(report.Missing, *order)
+		}
+	}
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+
+	// Check processed events the API did not report as paid within the window
+	processed, err := r.Store.ListProcessed(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processed orders: %w", err)
+	}
+	for _, data := range processed {
+		if paid[data.WordgateOrderNo] {
+			continue
+		}
+
+		order, err := r.Client.GetAppOrder(data.WordgateOrderNo)
+		if err != nil {
+			var apiErr APIError
+			var httpErr *HTTPError
+			if (errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) ||
+				(errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound) {
+				// The API does not know the order
+				report.Unexpected = append(report.Unexpected, data)
+				continue
+			}
+			return nil, err
+		}
+		if !order.IsPaid {
+			report.Unexpected = append(report.Unexpected, data)
+		}
+	}
+
+	// Emit synthetic events for missing orders
+	if r.OnMissing != nil {
+		for _, order := range report.Missing {
+			if err := r.OnMissing(ctx, r.syntheticEvent(&order)); err != nil {
+				report.EmitErrors = append(report.EmitErrors, fmt.Errorf("order %s: %w", order.OrderNo, err))
+				continue
+			}
+			report.Emitted++
+		}
+	}
+
+	return report, nil
+}
+
+// Run reconciles the last Window every Interval until the context is cancelled
+//
+// ctx: Context controlling the lifetime of the loop
+// Returns the context error once cancelled
+func (r *Reconciler) Run(ctx context.Context) error {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		end := time.Now()
+		report, err := r.Reconcile(ctx, end.Add(-r.Window), end)
+		if err != nil {
+			if r.OnError != nil {
+				r.OnError(err)
+			}
+		} else if r.OnReport != nil {
+			r.OnReport(report)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// syntheticEvent builds an order.paid event from an order list item
+func (r *Reconciler) syntheticEvent(order *OrderListItem) *WebhookEventData {
+	return &WebhookEventData{
+		EventType: WebhookEventOrderPaid,
+		AppID:     r.AppID,
+		Data: WebhookOrderPaidData{
+			WordgateOrderNo: order.OrderNo,
+			Amount:          order.Amount,
+			Currency:        order.Currency,
+			IsPaid:          order.IsPaid,
+			PaidAt:          order.PaidAt,
+			AppID:           r.AppID,
+		},
+		Timestamp: time.Now().Unix(),
+	}
+}
@@ -0,0 +1,184 @@
+package wordgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// reconcileTestServer serves paginated paid orders and order details; unknown orders get
+// the given status and body
+func reconcileTestServer(t *testing.T, orders []OrderListItem, notFoundStatus int, notFoundBody string) *httptest.Server {
+	t.Helper()
+	writeData := func(w http.ResponseWriter, data interface{}) {
+		json.NewEncoder(w).Encode(APIResponse{Code: 0, Data: data})
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/app/orders" {
+			var paid []OrderListItem
+			for _, order := range orders {
+				if order.IsPaid {
+					paid = append(paid, order)
+				}
+			}
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			start := (page - 1) * limit
+			end := min(start+limit, len(paid))
+			start = min(start, end)
+			writeData(w, ListResult{
+				Data:       paid[start:end],
+				Pagination: &Pagination{Page: page, Limit: limit, Total: int64(len(paid)), TotalPages: (len(paid) + limit - 1) / limit},
+			})
+			return
+		}
+
+		orderNo := strings.TrimPrefix(r.URL.Path, "/app/orders/")
+		for _, order := range orders {
+			if order.OrderNo == orderNo {
+				writeData(w, OrderDetailResponse{OrderNo: order.OrderNo, IsPaid: order.IsPaid})
+				return
+			}
+		}
+		w.WriteHeader(notFoundStatus)
+		w.Write([]byte(notFoundBody))
+	}))
+}
+
+func TestReconcilerFindsMissingAndUnexpectedOrders(t *testing.T) {
+	end := time.Now().Truncate(time.Second)
+	start := end.Add(-24 * time.Hour)
+	inWindow := end.Add(-time.Hour)
+	beforeWindow := start.Add(-time.Hour)
+
+	server := reconcileTestServer(t, []OrderListItem{
+		{OrderNo: "WG1", IsPaid: true, PaidAt: &inWindow, Amount: 100, Currency: "USD"},
+		{OrderNo: "WG2", IsPaid: true, PaidAt: &inWindow, Amount: 200, Currency: "USD"},
+		{OrderNo: "WG3", IsPaid: true, PaidAt: &beforeWindow},
+		{OrderNo: "WG4", IsPaid: true, PaidAt: &inWindow, Amount: 400, Currency: "USD"},
+		{OrderNo: "WG5", IsPaid: false},
+	}, http.StatusNotFound, `{"code":404,"msg":"order not found"}`)
+	defer server.Close()
+
+	store := NewMemoryProcessedOrderStore()
+	for _, orderNo := range []string{"WG1", "WG5", "WG404"} {
+		store.MarkProcessed(WebhookOrderPaidData{WordgateOrderNo: orderNo, PaidAt: &inWindow})
+	}
+
+	reconciler := NewReconciler(NewClient("app", "secret", server.URL), store)
+	reconciler.PageSize = 2
+	var emitted []string
+	reconciler.OnMissing = func(ctx context.Context, event *WebhookEventData) error {
+		data := event.Data.(WebhookOrderPaidData)
+		if data.WordgateOrderNo == "WG4" {
+			return errors.New("downstream unavailable")
+		}
+		emitted = append(emitted, data.WordgateOrderNo)
+		return nil
+	}
+
+	report, err := reconciler.Reconcile(context.Background(), start, end)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if report.Checked != 3 {
+		t.Errorf("Checked = %d, want 3", report.Checked)
+	}
+	var missing []string
+	for _, order := range report.Missing {
+		missing = append(missing, order.OrderNo)
+	}
+	if strings.Join(missing, ",") != "WG2,WG4" {
+		t.Errorf("Missing = %v, want [WG2 WG4]", missing)
+	}
+	var unexpected []string
+	for _, data := range report.Unexpected {
+		unexpected = append(unexpected, data.WordgateOrderNo)
+	}
+	sort.Strings(unexpected)
+	if strings.Join(unexpected, ",") != "WG404,WG5" {
+		t.Errorf("Unexpected = %v, want [WG404 WG5]", unexpected)
+	}
+	if report.Emitted != 1 || len(emitted) != 1 || emitted[0] != "WG2" {
+		t.Errorf("Emitted = %d (%v), want 1 (WG2)", report.Emitted, emitted)
+	}
+	if len(report.EmitErrors) != 1 || !strings.Contains(report.EmitErrors[0].Error(), "WG4") {
+		t.Errorf("EmitErrors = %v, want one error for WG4", report.EmitErrors)
+	}
+}
+
+func TestTrackProcessedOrdersOnlyMarksSuccessfulPaidEvents(t *testing.T) {
+	store := NewMemoryProcessedOrderStore()
+	fail := true
+	process := TrackProcessedOrders(store, func(ctx context.Context, event *WebhookEventData) error {
+		if fail {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	})
+
+	event := &WebhookEventData{EventType: WebhookEventOrderPaid, Data: WebhookOrderPaidData{WordgateOrderNo: "WG1"}}
+	if err := process(context.Background(), event); err == nil {
+		t.Fatal("expected process error")
+	}
+	if processed, _ := store.IsProcessed("WG1"); processed {
+		t.Fatal("failed event was marked as processed")
+	}
+
+	fail = false
+	if err := process(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	if processed, _ := store.IsProcessed("WG1"); !processed {
+		t.Fatal("successful event was not marked as processed")
+	}
+}
+
+func TestReconcilerReportsOrdersUnknownToTheAPI(t *testing.T) {
+	end := time.Now().Truncate(time.Second)
+	start := end.Add(-24 * time.Hour)
+	paidAt := end.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+	}{
+		{name: "envelope with 404 code", status: http.StatusNotFound, body: `{"code":404,"msg":"order not found"}`},
+		{name: "envelope with business code", status: http.StatusNotFound, body: `{"code":40401,"msg":"order not found"}`},
+		{name: "non-envelope body", status: http.StatusNotFound, body: `<html>not found</html>`},
+		{name: "server error", status: http.StatusInternalServerError, body: `{"code":500,"msg":"internal error"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := reconcileTestServer(t, nil, tt.status, tt.body)
+			defer server.Close()
+
+			store := NewMemoryProcessedOrderStore()
+			store.MarkProcessed(WebhookOrderPaidData{WordgateOrderNo: "WG404", PaidAt: &paidAt})
+			report, err := NewReconciler(NewClient("app", "secret", server.URL), store).Reconcile(context.Background(), start, end)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Reconcile() succeeded, want the server error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if len(report.Unexpected) != 1 || report.Unexpected[0].WordgateOrderNo != "WG404" {
+				t.Fatalf("Unexpected = %+v, want [WG404]", report.Unexpected)
+			}
+		})
+	}
+}
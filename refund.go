@@ -0,0 +1,158 @@
+package wordgate
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RefundStatus represents the status of a refund
+type RefundStatus string
+
+const (
+	// RefundStatusPending indicates the refund is being processed by the payment provider
+	RefundStatusPending RefundStatus = "pending"
+	// RefundStatusSucceeded indicates the refund was completed
+	RefundStatusSucceeded RefundStatus = "succeeded"
+	// RefundStatusFailed indicates the payment provider rejected the refund
+	RefundStatusFailed RefundStatus = "failed"
+)
+
+// Refund represents a refund of a paid order
+type Refund struct {
+	// ID is the refund database ID
+	ID uint64 `json:"id"`
+	// RefundNo is the unique refund number
+	RefundNo string `json:"refund_no"`
+	// OrderNo is the refunded order number
+	OrderNo string `json:"order_no"`
+	// Amount is the refunded amount in cents
+	Amount int64 `json:"amount"`
+	// Currency is the currency code
+	Currency string `json:"currency"`
+	// IsFullRefund indicates whether the whole order amount was refunded
+	IsFullRefund bool `json:"is_full_refund"`
+	// Status is the refund status
+	Status RefundStatus `json:"status"`
+	// Reason is the refund reason
+	Reason string `json:"reason"`
+	// IdempotencyKey is the idempotency key the refund was created with
+	IdempotencyKey string `json:"idempotency_key"`
+	// CreatedAt is the creation timestamp
+	CreatedAt time.Time `json:"created_at"`
+	// RefundedAt is the completion timestamp (nil if not completed)
+	RefundedAt *time.Time `json:"refunded_at"`
+}
+
+// RefundOrderRequest represents a request to refund a paid order
+type RefundOrderRequest struct {
+	// Amount is the refund amount in cents (optional, defaults to the remaining refundable amount)
+	Amount *int64 `json:"amount,omitempty"`
+	// Reason is the refund reason (required)
+	Reason string `json:"reason" binding:"required"`
+	// IdempotencyKey makes retries of the same refund safe; generated when empty
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+// ListRefundsQuery represents query parameters for listing refunds
+type ListRefundsQuery struct {
+	// OrderNo filters refunds by order number (optional)
+	OrderNo string `json:"order_no,omitempty"`
+	// Status filters refunds by status (optional)
+	Status RefundStatus `json:"status,omitempty"`
+	// Page is the page number (starting from 1)
+	Page int `json:"page,omitempty"`
+	// Limit is the number of items per page
+	Limit int `json:"limit,omitempty"`
+}
+
+// RefundListResponse represents a paginated list of refunds
+type RefundListResponse struct {
+	// Data is the list of refunds
+	Data []Refund `json:"data"`
+	// Pagination contains pagination information
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// RefundOrder refunds a paid order fully or partially
+//
+// When request.IdempotencyKey is empty a key is generated and stored in the request,
+// so retrying with the same request does not refund twice.
+// Returns ErrOrderNotPaid if the order is not paid and ErrRefundExceedsPaidAmount if the
+// amount exceeds what is left to refund; compare with errors.Is
+//
+// orderNo: The order number to refund
+// request: The refund request containing amount and reason
+// Returns the created refund and any error
+func (c *Client) RefundOrder(orderNo string, request *RefundOrderRequest) (*Refund, error) {
+	if request == nil || request.Reason == "" {
+		return nil, fmt.Errorf("failed to refund order: reason is required")
+	}
+	if request.Amount != nil && *request.Amount <= 0 {
+		return nil, fmt.Errorf("failed to refund order: amount must be positive")
+	}
+	if request.IdempotencyKey == "" {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to refund order: %w", err)
+		}
+		request.IdempotencyKey = key
+	}
+
+	var result Refund
+	path := fmt.Sprintf("/app/orders/%s/refunds", url.PathEscape(orderNo))
+	err := c.requestJSON("POST", path, request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refund order: %w", err)
+	}
+	return &result, nil
+}
+
+// ListRefunds retrieves a paginated list of refunds
+//
+// query: The query parameters for filtering and pagination
+// Returns the refund list with pagination information and any error
+func (c *Client) ListRefunds(query *ListRefundsQuery) (*RefundListResponse, error) {
+	// Build query parameters
+	params := url.Values{}
+
+	if query != nil {
+		if query.OrderNo != "" {
+			params.Set("order_no", query.OrderNo)
+		}
+		if query.Status != "" {
+			params.Set("status", string(query.Status))
+		}
+		if query.Page > 0 {
+			params.Set("page", strconv.Itoa(query.Page))
+		}
+		if query.Limit > 0 {
+			params.Set("limit", strconv.Itoa(query.Limit))
+		}
+	}
+
+	// Build path with query parameters
+	path := "/app/refunds"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var result RefundListResponse
+	err := c.requestJSON("GET", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refunds: %w", err)
+	}
+	return &result, nil
+}
+
+// newIdempotencyKey generates a random idempotency key
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
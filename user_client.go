@@ -0,0 +1,266 @@
+package wordgate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// UserClient is a WordGate client API client acting on behalf of a signed-in end user
+//
+// It authenticates with the user's access token instead of the app secret, so it only
+// reaches the user's own orders, membership and addresses and is safe to use in code
+// that must not hold the app secret.
+type UserClient struct {
+	// AppCode is the application code the user belongs to
+	AppCode string
+	// Token is the end user's access token
+	Token string
+	// BaseURL is the base URL of the WordGate API
+	BaseURL string
+	// HTTPClient is the HTTP client used for requests
+	HTTPClient *http.Client
+}
+
+// CreateProductOrderRequest represents a request to create a product order as the signed-in user
+type CreateProductOrderRequest struct {
+	// Items is the list of product items
+	Items []OrderItem `json:"items"`
+	// CouponCode is an optional coupon code
+	CouponCode string `json:"coupon_code,omitempty"`
+	// AddressID is the shipping address ID (required if any product requires an address)
+	AddressID uint64 `json:"address_id,omitempty"`
+	// RedirectURL is the payment completion redirect URL (optional)
+	RedirectURL string `json:"redirect_url,omitempty"`
+	// ExternalRef is the caller's own reference for the order, e.g. a cart ID (optional)
+	ExternalRef string `json:"external_ref,omitempty"`
+	// Metadata is arbitrary key/value data stored with the order (optional)
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// CreateMembershipOrderRequest represents a request to create a membership order as the signed-in user
+type CreateMembershipOrderRequest struct {
+	// TierID is the membership tier ID
+	TierID uint64 `json:"tier_id"`
+	// PeriodType is the membership period type
+	PeriodType MembershipPeriodType `json:"period_type"`
+	// CouponCode is an optional coupon code
+	CouponCode string `json:"coupon_code,omitempty"`
+	// RedirectURL is the payment completion redirect URL (optional)
+	RedirectURL string `json:"redirect_url,omitempty"`
+	// ExternalRef is the caller's own reference for the order, e.g. a cart ID (optional)
+	ExternalRef string `json:"external_ref,omitempty"`
+	// Metadata is arbitrary key/value data stored with the order (optional)
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ListMyOrdersRequest represents a request to list the signed-in user's orders
+type ListMyOrdersRequest struct {
+	// Page is the page number (starting from 1)
+	Page int `json:"page,omitempty"`
+	// Limit is the number of items per page
+	Limit int `json:"limit,omitempty"`
+	// Status filters orders by payment status (paid/unpaid)
+	Status string `json:"status,omitempty"`
+}
+
+// NewUserClient creates a new WordGate client API client for an end user
+//
+// appCode: The application code the user belongs to
+// token: The end user's access token
+// baseURL: The base URL of the WordGate API (e.g., "https://api.wordgate.example.com")
+func NewUserClient(appCode, token, baseURL string) *UserClient {
+	return &UserClient{
+		AppCode: appCode,
+		Token:   token,
+		BaseURL: baseURL,
+		HTTPClient: &http.Client{
+			Timeout: time.Second * 30,
+		},
+	}
+}
+
+// requestJSON performs an HTTP request with the user's token and unmarshals the JSON response
+//
+// method: HTTP method (GET, POST, etc.)
+// path: API endpoint path
+// body: Request body (will be JSON encoded if not nil)
+// result: Pointer to the result structure
+func (c *UserClient) requestJSON(method, path string, body interface{}, result interface{}) error {
+	req, err := newJSONRequest(context.Background(), method, c.BaseURL+path, body)
+	if err != nil {
+		return err
+	}
+
+	// Set authentication headers
+	req.Header.Set("X-App-Code", c.AppCode)
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+
+	// Send request
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	return decodeResponse(resp, result)
+}
+
+// ListOrders retrieves a paginated list of the user's orders
+//
+// request: The list request containing filter and pagination parameters (optional)
+// Returns the user's orders with pagination information and any error
+func (c *UserClient) ListOrders(request *ListMyOrdersRequest) (*UserOrderList, error) {
+	// Build query parameters
+	params := url.Values{}
+
+	if request != nil {
+		if request.Page > 0 {
+			params.Set("page", strconv.Itoa(request.Page))
+		}
+		if request.Limit > 0 {
+			params.Set("limit", strconv.Itoa(request.Limit))
+		}
+		if request.Status != "" {
+			params.Set("status", request.Status)
+		}
+	}
+
+	// Build path with query parameters
+	path := "/orders"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var result UserOrderList
+	err := c.requestJSON("GET", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	return &result, nil
+}
+
+// GetOrder retrieves one of the user's orders by order number
+//
+// orderNo: The order number to retrieve
+// Returns the detailed order information and any error
+func (c *UserClient) GetOrder(orderNo string) (*OrderDetailResponse, error) {
+	var result OrderDetailResponse
+	err := c.requestJSON("GET", "/orders/"+url.PathEscape(orderNo), nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	return &result, nil
+}
+
+// CreateProductOrder creates a product order for the user
+//
+// request: The product order creation request containing items
+// Returns the created order information and any error
+func (c *UserClient) CreateProductOrder(request *CreateProductOrderRequest) (*OrderSummaryResponse, error) {
+	var result OrderSummaryResponse
+	err := c.requestJSON("POST", "/product-orders/create", request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product order: %w", err)
+	}
+	return &result, nil
+}
+
+// CreateMembershipOrder creates a membership order for the user
+//
+// request: The membership order creation request containing tier and period info
+// Returns the created order information and any error
+func (c *UserClient) CreateMembershipOrder(request *CreateMembershipOrderRequest) (*OrderSummaryResponse, error) {
+	var result OrderSummaryResponse
+	err := c.requestJSON("POST", "/membership-orders/create", request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create membership order: %w", err)
+	}
+	return &result, nil
+}
+
+// GetMembership retrieves the user's current membership and membership history
+//
+// Returns the membership details and any error
+func (c *UserClient) GetMembership() (*UserMembershipDetail, error) {
+	var result UserMembershipDetail
+	err := c.requestJSON("GET", "/membership", nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get membership: %w", err)
+	}
+	return &result, nil
+}
+
+// ListAddresses retrieves all addresses of the user
+//
+// Returns the user's addresses and any error
+func (c *UserClient) ListAddresses() ([]UserAddress, error) {
+	var result []UserAddress
+	err := c.requestJSON("GET", "/addresses", nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list addresses: %w", err)
+	}
+	return result, nil
+}
+
+// CreateAddress creates a new address for the user
+//
+// request: The address details; required fields are validated before the request is sent
+// Returns the created address and any error
+func (c *UserClient) CreateAddress(request *UserAddressRequest) (*UserAddress, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to create address: %w", err)
+	}
+
+	var result UserAddress
+	err := c.requestJSON("POST", "/addresses", request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create address: %w", err)
+	}
+	return &result, nil
+}
+
+// UpdateAddress updates an existing address of the user
+//
+// addressID: The address ID to update
+// request: The new address details; required fields are validated before the request is sent
+// Returns the updated address and any error
+func (c *UserClient) UpdateAddress(addressID uint64, request *UserAddressRequest) (*UserAddress, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to update address: %w", err)
+	}
+
+	var result UserAddress
+	err := c.requestJSON("PUT", fmt.Sprintf("/addresses/%d", addressID), request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update address: %w", err)
+	}
+	return &result, nil
+}
+
+// DeleteAddress deletes an address of the user
+//
+// addressID: The address ID to delete
+// Returns any error encountered during deletion
+func (c *UserClient) DeleteAddress(addressID uint64) error {
+	var result map[string]interface{}
+	err := c.requestJSON("DELETE", fmt.Sprintf("/addresses/%d", addressID), nil, &result)
+	if err != nil {
+		return fmt.Errorf("failed to delete address: %w", err)
+	}
+	return nil
+}
+
+// SetDefaultAddress makes an address the user's default address
+//
+// addressID: The address ID to make default
+// Returns the updated address and any error
+func (c *UserClient) SetDefaultAddress(addressID uint64) (*UserAddress, error) {
+	var result UserAddress
+	err := c.requestJSON("POST", fmt.Sprintf("/addresses/%d/default", addressID), nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set default address: %w", err)
+	}
+	return &result, nil
+}
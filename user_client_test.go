@@ -0,0 +1,76 @@
+package wordgate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUserClientAuthenticatesWithTokenOnly(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		if got := r.Header.Get("Authorization"); got != "Bearer user_token" {
+			t.Errorf("%s %s: Authorization = %q, want bearer token", r.Method, r.URL.Path, got)
+		}
+		if got := r.Header.Get("X-App-Code"); got != "app" {
+			t.Errorf("%s %s: X-App-Code = %q, want app", r.Method, r.URL.Path, got)
+		}
+		if _, ok := r.Header["X-App-Secret"]; ok {
+			t.Errorf("%s %s: X-App-Secret was sent", r.Method, r.URL.Path)
+		}
+		if r.Method == "GET" && r.URL.Path == "/addresses" {
+			w.Write([]byte(`{"code":0,"data":[]}`))
+			return
+		}
+		w.Write([]byte(`{"code":0,"data":{}}`))
+	}))
+	defer server.Close()
+
+	client := NewUserClient("app", "user_token", server.URL)
+	address := &UserAddressRequest{Name: "Li", Phone: "13800000000", Province: "Beijing", City: "Beijing", Street: "1 Main St"}
+	calls := []struct {
+		want string
+		call func() error
+	}{
+		{"GET /orders?limit=10&page=2&status=paid", func() error {
+			_, err := client.ListOrders(&ListMyOrdersRequest{Page: 2, Limit: 10, Status: "paid"})
+			return err
+		}},
+		{"GET /orders", func() error { _, err := client.ListOrders(nil); return err }},
+		{"GET /orders/WG%2F1", func() error { _, err := client.GetOrder("WG/1"); return err }},
+		{"POST /product-orders/create", func() error {
+			_, err := client.CreateProductOrder(&CreateProductOrderRequest{Items: []OrderItem{{ItemCode: "BOOK", Quantity: 1}}})
+			return err
+		}},
+		{"POST /membership-orders/create", func() error {
+			_, err := client.CreateMembershipOrder(&CreateMembershipOrderRequest{TierID: 1, PeriodType: PeriodTypeMonth})
+			return err
+		}},
+		{"GET /membership", func() error { _, err := client.GetMembership(); return err }},
+		{"GET /addresses", func() error { _, err := client.ListAddresses(); return err }},
+		{"POST /addresses", func() error { _, err := client.CreateAddress(address); return err }},
+		{"PUT /addresses/7", func() error { _, err := client.UpdateAddress(7, address); return err }},
+		{"DELETE /addresses/7", func() error { return client.DeleteAddress(7) }},
+		{"POST /addresses/7/default", func() error { _, err := client.SetDefaultAddress(7); return err }},
+	}
+	for _, c := range calls {
+		requests = nil
+		if err := c.call(); err != nil {
+			t.Fatalf("%s: error = %v", c.want, err)
+		}
+		if len(requests) != 1 || requests[0] != c.want {
+			t.Fatalf("requests = %v, want [%s]", requests, c.want)
+		}
+	}
+
+	// Invalid addresses are rejected before any request is sent
+	requests = nil
+	if _, err := client.CreateAddress(&UserAddressRequest{Name: "Li"}); err == nil || !strings.Contains(err.Error(), "phone") {
+		t.Fatalf("CreateAddress(invalid) error = %v, want missing fields", err)
+	}
+	if len(requests) != 0 {
+		t.Fatalf("invalid address sent requests %v", requests)
+	}
+}
@@ -2,7 +2,8 @@
 Webhook handling utilities for WordGate API.
 
 This file provides structures and utilities for handling webhook events from WordGate,
-including order payments, cancellations, and subscription events.
+including order payments, cancellations, refunds, membership lifecycle,
+user lifecycle and payment failure events.
 
 Usage example:
 
@@ -37,6 +38,22 @@ Usage example:
 
 		}
 
+		// Alternatively, decode into the typed payload for the event type
+		payload, err := webhookEvent.Decode()
+		if err != nil {
+			http.Error(w, "Unsupported event", http.StatusBadRequest)
+			return
+		}
+
+		switch data := payload.(type) {
+		case *wordgate.WebhookOrderRefundedData:
+			log.Printf("Order %s refunded: %d %s", data.WordgateOrderNo, data.RefundAmount, data.Currency)
+		case *wordgate.WebhookMembershipExpiredData:
+			log.Printf("Membership %s of user %d expired", data.TierCode, data.UserID)
+		case *wordgate.WebhookPaymentFailedData:
+			log.Printf("Payment for order %s failed: %s", data.WordgateOrderNo, data.FailureMessage)
+		}
+
 		w.WriteHeader(http.StatusOK)
 	}
 */
@@ -47,6 +64,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -71,6 +89,19 @@ func (w *WebhookEventData) Parse(target any) error {
 	return json.Unmarshal(jsonBytes, target)
 }
 
+// Decode 按事件类型将事件数据解析为对应的结构体指针，如 *WebhookOrderPaidData
+func (w *WebhookEventData) Decode() (any, error) {
+	payload := NewWebhookEventPayload(w.EventType)
+	if payload == nil {
+		return nil, fmt.Errorf("unknown webhook event type: %s", w.EventType)
+	}
+
+	if err := w.Parse(payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
 // WebhookOrderPaidData 订单支付成功事件的数据结构
 type WebhookOrderPaidData struct {
 	WordgateOrderNo string     `json:"wordgate_order_no"` // 订单号
@@ -99,13 +130,146 @@ type WebhookMembershipActivatedData struct {
 	AppID     uint64 `json:"app_id"`     // 应用ID
 }
 
+// WebhookOrderRefundedData 订单退款事件的数据结构
+type WebhookOrderRefundedData struct {
+	WordgateOrderNo string     `json:"wordgate_order_no"` // 订单号
+	RefundNo        string     `json:"refund_no"`         // 退款单号
+	Amount          int64      `json:"amount"`            // 订单金额
+	RefundAmount    int64      `json:"refund_amount"`     // 本次退款金额
+	Currency        string     `json:"currency"`          // 货币类型
+	IsFullRefund    bool       `json:"is_full_refund"`    // 是否全额退款
+	RefundedAt      *time.Time `json:"refunded_at"`       // 退款时间
+	AppID           uint64     `json:"app_id"`            // 应用ID
+	Reason          string     `json:"reason"`            // 退款原因
+}
+
+// WebhookMembershipExpiredData 会员到期事件的数据结构
+type WebhookMembershipExpiredData struct {
+	UserID    uint64 `json:"user_id"`    // 用户ID
+	TierCode  string `json:"tier_code"`  // 会员等级代码
+	ExpiredAt string `json:"expired_at"` // 到期时间 (ISO格式)
+	AppID     uint64 `json:"app_id"`     // 应用ID
+}
+
+// WebhookMembershipCancelledData 会员取消事件的数据结构
+type WebhookMembershipCancelledData struct {
+	UserID      uint64 `json:"user_id"`      // 用户ID
+	TierCode    string `json:"tier_code"`    // 会员等级代码
+	CancelledAt string `json:"cancelled_at"` // 取消时间 (ISO格式)
+	AppID       uint64 `json:"app_id"`       // 应用ID
+	Reason      string `json:"reason"`       // 取消原因
+}
+
+// WebhookMembershipRenewedData 会员续费事件的数据结构
+type WebhookMembershipRenewedData struct {
+	UserID            uint64 `json:"user_id"`             // 用户ID
+	TierCode          string `json:"tier_code"`           // 会员等级代码
+	PreviousExpiresAt string `json:"previous_expires_at"` // 续费前到期时间 (ISO格式)
+	ExpiresAt         string `json:"expires_at"`          // 续费后到期时间 (ISO格式)
+	OrderNo           string `json:"order_no"`            // 关联订单号
+	AppID             uint64 `json:"app_id"`              // 应用ID
+}
+
+// WebhookUserCreatedData 用户创建事件的数据结构
+type WebhookUserCreatedData struct {
+	UserID    uint64     `json:"user_id"`    // 用户ID
+	UID       string     `json:"uid"`        // 用户唯一标识
+	Nickname  string     `json:"nickname"`   // 昵称
+	Email     string     `json:"email"`      // 邮箱
+	CreatedAt *time.Time `json:"created_at"` // 创建时间
+	AppID     uint64     `json:"app_id"`     // 应用ID
+}
+
+// WebhookUserDisabledData 用户禁用事件的数据结构
+type WebhookUserDisabledData struct {
+	UserID     uint64     `json:"user_id"`     // 用户ID
+	UID        string     `json:"uid"`         // 用户唯一标识
+	DisabledAt *time.Time `json:"disabled_at"` // 禁用时间
+	AppID      uint64     `json:"app_id"`      // 应用ID
+	Reason     string     `json:"reason"`      // 禁用原因
+}
+
+// WebhookPaymentFailedData 支付失败事件的数据结构
+type WebhookPaymentFailedData struct {
+	WordgateOrderNo string     `json:"wordgate_order_no"` // 订单号
+	Amount          int64      `json:"amount"`            // 支付金额
+	Currency        string     `json:"currency"`          // 货币类型
+	Provider        string     `json:"provider"`          // 支付渠道
+	IntentID        string     `json:"intent_id"`         // 支付平台生成的支付意图ID
+	FailureCode     string     `json:"failure_code"`      // 失败代码
+	FailureMessage  string     `json:"failure_message"`   // 失败原因描述
+	FailedAt        *time.Time `json:"failed_at"`         // 失败时间
+	AppID           uint64     `json:"app_id"`            // 应用ID
+}
+
 // WebhookEventType 定义支持的webhook事件类型常量
 type WebhookEventType string
 
 const (
 	WebhookEventOrderPaid           WebhookEventType = "order.paid"           // 订单支付成功
 	WebhookEventOrderCancelled      WebhookEventType = "order.cancelled"      // 订单取消
+	WebhookEventOrderRefunded       WebhookEventType = "order.refunded"       // 订单退款
 	WebhookEventMembershipActivated WebhookEventType = "membership.activated" // 会员变动
+	WebhookEventMembershipExpired   WebhookEventType = "membership.expired"   // 会员到期
+	WebhookEventMembershipCancelled WebhookEventType = "membership.cancelled" // 会员取消
+	WebhookEventMembershipRenewed   WebhookEventType = "membership.renewed"   // 会员续费
+	WebhookEventUserCreated         WebhookEventType = "user.created"         // 用户创建
+	WebhookEventUserDisabled        WebhookEventType = "user.disabled"        // 用户禁用
+	WebhookEventPaymentFailed       WebhookEventType = "payment.failed"       // 支付失败
+)
+
+// WebhookEventTypes 返回SDK已知的全部事件类型
+func WebhookEventTypes() []WebhookEventType {
+	return []WebhookEventType{
+		WebhookEventOrderPaid,
+		WebhookEventOrderCancelled,
+		WebhookEventOrderRefunded,
+		WebhookEventMembershipActivated,
+		WebhookEventMembershipExpired,
+		WebhookEventMembershipCancelled,
+		WebhookEventMembershipRenewed,
+		WebhookEventUserCreated,
+		WebhookEventUserDisabled,
+		WebhookEventPaymentFailed,
+	}
+}
+
+// NewWebhookEventPayload 返回事件类型对应的数据结构指针，未知事件类型返回nil
+func NewWebhookEventPayload(eventType WebhookEventType) any {
+	switch eventType {
+	case WebhookEventOrderPaid:
+		return &WebhookOrderPaidData{}
+	case WebhookEventOrderCancelled:
+		return &WebhookOrderCancelledData{}
+	case WebhookEventOrderRefunded:
+		return &WebhookOrderRefundedData{}
+	case WebhookEventMembershipActivated:
+		return &WebhookMembershipActivatedData{}
+	case WebhookEventMembershipExpired:
+		return &WebhookMembershipExpiredData{}
+	case WebhookEventMembershipCancelled:
+		return &WebhookMembershipCancelledData{}
+	case WebhookEventMembershipRenewed:
+		return &WebhookMembershipRenewedData{}
+	case WebhookEventUserCreated:
+		return &WebhookUserCreatedData{}
+	case WebhookEventUserDisabled:
+		return &WebhookUserDisabledData{}
+	case WebhookEventPaymentFailed:
+		return &WebhookPaymentFailedData{}
+	default:
+		return nil
+	}
+}
+
+// WebhookSignatureHeader webhook签名所在的HTTP header名称
+const WebhookSignatureHeader = "X-Webhook-Signature"
+
+var (
+	// ErrWebhookTimestampTooOld 签名时间戳超出允许的时间差
+	ErrWebhookTimestampTooOld = errors.New("timestamp too old")
+	// ErrWebhookSignatureMismatch 签名与请求体不匹配
+	ErrWebhookSignatureMismatch = errors.New("signature verification failed")
 )
 
 // WebhookSignature webhook签名相关结构体
@@ -187,16 +351,21 @@ func VerifySignature(headerValue string, body []byte, secret string, maxTimeDiff
 	// 检查时间戳，防重放攻击
 	now := time.Now().Unix()
 	if now-webhookSig.Timestamp > maxTimeDiff {
-		return fmt.Errorf("timestamp too old: %d seconds ago", now-webhookSig.Timestamp)
+		return fmt.Errorf("%w: %d seconds ago", ErrWebhookTimestampTooOld, now-webhookSig.Timestamp)
 	}
 	
+	return verifySignatureDigest(webhookSig, body, secret)
+}
+
+// verifySignatureDigest 验证签名值，不检查时间戳
+func verifySignatureDigest(webhookSig *WebhookSignature, body []byte, secret string) error {
 	// 生成预期签名
 	expectedSignature := GenerateSignature(webhookSig.Timestamp, body, secret)
-	
+
 	// 使用常量时间比较，防时序攻击
 	if !hmac.Equal([]byte(webhookSig.Signature), []byte(expectedSignature)) {
-		return fmt.Errorf("signature verification failed")
+		return ErrWebhookSignatureMismatch
 	}
-	
+
 	return nil
 }
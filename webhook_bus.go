@@ -0,0 +1,329 @@
+/*
+In-process event bus for WordGate webhook events.
+
+The bus fans out verified webhook events to any number of in-process subscribers. Subscribers
+register for a WebhookEventType (or WebhookEventAll) and receive typed events either over a
+channel or through a callback. Each subscriber has its own buffer and backpressure policy, and
+Close drains buffered events before returning.
+
+Usage example:
+
+	bus := wordgate.NewWebhookBus()
+	http.Handle("/webhook", wordgate.NewWebhookHandler("your_webhook_secret", bus.Publish))
+
+	// Channel subscriber
+	paid := bus.Subscribe(wordgate.WebhookEventOrderPaid, &wordgate.SubscribeOptions{Buffer: 100})
+	go func() {
+		for event := range paid.C() {
+			data := event.Payload.(*wordgate.WebhookOrderPaidData)
+			log.Printf("Order %s paid", data.WordgateOrderNo)
+		}
+	}()
+
+	// Callback subscriber for every event, dropping the oldest event when it falls behind
+	bus.SubscribeFunc(wordgate.WebhookEventAll, func(ctx context.Context, event wordgate.BusEvent) {
+		metrics.Count(string(event.Type))
+	}, &wordgate.SubscribeOptions{Buffer: 1000, Policy: wordgate.BackpressureDropOldest})
+
+	// On shutdown, wait up to 10 seconds for in-flight events to be handled
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	bus.Close(ctx)
+*/
+package wordgate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// WebhookEventAll 订阅全部事件类型的通配符
+const WebhookEventAll WebhookEventType = "*"
+
+// ErrWebhookBusClosed 事件总线已关闭
+var ErrWebhookBusClosed = errors.New("webhook bus closed")
+
+// BackpressurePolicy 订阅者缓冲区已满时的处理策略
+type BackpressurePolicy int
+
+const (
+	BackpressureBlock      BackpressurePolicy = iota // 阻塞发布方直到有空间或上下文取消
+	BackpressureDropNewest                           // 丢弃新事件
+	BackpressureDropOldest                           // 丢弃缓冲区中最旧的事件
+)
+
+// BusEvent 事件总线分发给订阅者的事件
+type BusEvent struct {
+	Type    WebhookEventType  // 事件类型
+	Event   *WebhookEventData // 原始事件
+	Payload any               // 按事件类型解析后的数据，如 *WebhookOrderPaidData；未知事件类型为nil
+}
+
+// SubscribeOptions 订阅选项
+type SubscribeOptions struct {
+	Buffer int                // 缓冲区大小，默认16
+	Policy BackpressurePolicy // 缓冲区已满时的策略，默认阻塞
+}
+
+// Subscription 事件订阅
+type Subscription struct {
+	bus       *WebhookBus
+	eventType WebhookEventType
+	policy    BackpressurePolicy
+	ch        chan BusEvent
+	done      chan struct{}
+	finished  chan struct{} // 回调订阅者处理完缓冲事件后关闭
+	dropped   atomic.Uint64
+
+	mu       sync.RWMutex
+	closed   bool
+	stopOnce sync.Once
+}
+
+// C 返回接收事件的通道，取消订阅或总线关闭后通道会被关闭
+func (s *Subscription) C() <-chan BusEvent {
+	return s.ch
+}
+
+// Dropped 返回因背压策略被丢弃的事件数量
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe 取消订阅并关闭事件通道，缓冲区中尚未读取的事件仍可读出
+func (s *Subscription) Unsubscribe() {
+	s.bus.remove(s)
+	s.stop()
+}
+
+// stop 唤醒阻塞的发布方并关闭事件通道
+func (s *Subscription) stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		s.closed = true
+		close(s.ch)
+		s.mu.Unlock()
+	})
+}
+
+// matches 判断订阅是否接收该事件类型
+func (s *Subscription) matches(eventType WebhookEventType) bool {
+	return s.eventType == WebhookEventAll || s.eventType == eventType
+}
+
+// send 按背压策略投递事件
+func (s *Subscription) send(ctx context.Context, event BusEvent) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil
+	}
+
+	switch s.policy {
+	case BackpressureDropNewest:
+		select {
+		case s.ch <- event:
+		default:
+			s.dropped.Add(1)
+		}
+	case BackpressureDropOldest:
+		for {
+			select {
+			case s.ch <- event:
+				return nil
+			default:
+			}
+			select {
+			case <-s.ch:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- event:
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// WebhookBus 进程内webhook事件发布/订阅总线
+type WebhookBus struct {
+	mu       sync.RWMutex
+	subs     []*Subscription
+	closed   bool
+	inflight sync.WaitGroup
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// NewWebhookBus 创建事件总线
+func NewWebhookBus() *WebhookBus {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebhookBus{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Subscribe 订阅指定类型的事件，通过 Subscription.C 接收
+// eventType: 事件类型，WebhookEventAll 表示全部事件
+// opts: 订阅选项(可选)
+func (b *WebhookBus) Subscribe(eventType WebhookEventType, opts *SubscribeOptions) *Subscription {
+	sub := b.newSubscription(eventType, opts)
+	b.add(sub)
+	return sub
+}
+
+// SubscribeFunc 订阅指定类型的事件，由独立的goroutine按顺序调用回调函数
+// eventType: 事件类型，WebhookEventAll 表示全部事件
+// fn: 回调函数，ctx在总线关闭超时后被取消
+// opts: 订阅选项(可选)
+func (b *WebhookBus) SubscribeFunc(eventType WebhookEventType, fn func(ctx context.Context, event BusEvent), opts *SubscribeOptions) *Subscription {
+	sub := b.newSubscription(eventType, opts)
+	sub.finished = make(chan struct{})
+
+	go func() {
+		defer close(sub.finished)
+		for event := range sub.ch {
+			fn(b.ctx, event)
+		}
+	}()
+
+	// 所有字段初始化完成后才加入总线，Close读取finished时不会与此处竞争
+	b.add(sub)
+	return sub
+}
+
+// newSubscription 创建尚未加入总线的订阅
+func (b *WebhookBus) newSubscription(eventType WebhookEventType, opts *SubscribeOptions) *Subscription {
+	if opts == nil {
+		opts = &SubscribeOptions{}
+	}
+	buffer := opts.Buffer
+	if buffer <= 0 {
+		buffer = 16
+	}
+
+	return &Subscription{
+		bus:       b,
+		eventType: eventType,
+		policy:    opts.Policy,
+		ch:        make(chan BusEvent, buffer),
+		done:      make(chan struct{}),
+	}
+}
+
+// add 将订阅加入总线，总线已关闭时直接关闭订阅
+func (b *WebhookBus) add(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		sub.stop()
+		return
+	}
+	b.subs = append(b.subs, sub)
+}
+
+// Publish 将事件分发给所有匹配的订阅者，可直接作为 WebhookProcessFunc 使用
+//
+// 对阻塞策略的订阅者，Publish会等待其缓冲区有空间，ctx取消时返回错误
+func (b *WebhookBus) Publish(ctx context.Context, event *WebhookEventData) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrWebhookBusClosed
+	}
+	b.inflight.Add(1)
+	subs := make([]*Subscription, 0, len(b.subs))
+	for _, sub := range b.subs {
+		if sub.matches(event.EventType) {
+			subs = append(subs, sub)
+		}
+	}
+	b.mu.RUnlock()
+	defer b.inflight.Done()
+
+	busEvent := BusEvent{
+		Type:  event.EventType,
+		Event: event,
+	}
+	if payload, err := event.Decode(); err == nil {
+		busEvent.Payload = payload
+	}
+
+	for _, sub := range subs {
+		if err := sub.send(ctx, busEvent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close 停止接收新事件，等待进行中的发布完成并关闭所有订阅通道，
+// 然后等待回调订阅者处理完缓冲区中的事件；ctx到期时取消回调的上下文并返回错误
+func (b *WebhookBus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	subs := b.subs
+	b.subs = nil
+	b.mu.Unlock()
+
+	// 等待进行中的发布
+	published := make(chan struct{})
+	go func() {
+		b.inflight.Wait()
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-ctx.Done():
+		b.cancel()
+		for _, sub := range subs {
+			sub.stop()
+		}
+		return ctx.Err()
+	}
+
+	for _, sub := range subs {
+		sub.stop()
+	}
+
+	// 等待回调订阅者处理完缓冲事件
+	for _, sub := range subs {
+		if sub.finished == nil {
+			continue
+		}
+		select {
+		case <-sub.finished:
+		case <-ctx.Done():
+			b.cancel()
+			return ctx.Err()
+		}
+	}
+
+	b.cancel()
+	return nil
+}
+
+// remove 从总线中移除订阅
+func (b *WebhookBus) remove(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, s := range b.subs {
+		if s == sub {
+			b.subs = append(b.subs[:i], b.subs[i+1:]...)
+			return
+		}
+	}
+}
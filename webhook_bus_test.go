@@ -0,0 +1,76 @@
+package wordgate
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookBusCloseWhileSubscribing(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		bus := NewWebhookBus()
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for j := 0; j < 4; j++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				<-start
+				bus.SubscribeFunc(WebhookEventAll, func(ctx context.Context, event BusEvent) {}, nil)
+			}()
+			go func() {
+				defer wg.Done()
+				<-start
+				sub := bus.Subscribe(WebhookEventOrderPaid, nil)
+				for range sub.C() {
+				}
+			}()
+		}
+
+		closed := make(chan error, 1)
+		go func() {
+			<-start
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			closed <- bus.Close(ctx)
+		}()
+		close(start)
+
+		if err := <-closed; err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		wg.Wait()
+	}
+}
+
+func TestWebhookBusCloseDrainsCallbackSubscribers(t *testing.T) {
+	bus := NewWebhookBus()
+	var handled atomic.Int32
+	bus.SubscribeFunc(WebhookEventOrderPaid, func(ctx context.Context, event BusEvent) {
+		time.Sleep(time.Millisecond)
+		handled.Add(1)
+	}, &SubscribeOptions{Buffer: 10})
+
+	for i := 0; i < 10; i++ {
+		event := &WebhookEventData{EventType: WebhookEventOrderPaid, AppID: 1, Timestamp: int64(i)}
+		if err := bus.Publish(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := handled.Load(); got != 10 {
+		t.Fatalf("handled %d events before Close returned, want 10", got)
+	}
+
+	if err := bus.Publish(context.Background(), &WebhookEventData{EventType: WebhookEventOrderPaid}); err != ErrWebhookBusClosed {
+		t.Fatalf("Publish after Close error = %v, want ErrWebhookBusClosed", err)
+	}
+	sub := bus.Subscribe(WebhookEventAll, nil)
+	if _, ok := <-sub.C(); ok {
+		t.Fatal("subscription on closed bus delivered an event")
+	}
+}
@@ -0,0 +1,316 @@
+/*
+Webhook capture and replay for WordGate webhook events.
+
+A WebhookRecorder attached to a WebhookHandler records the raw body and signature headers of
+every verified webhook event to a JSON Lines file before it is processed, so events the handler
+failed on are captured too. Redeliveries of an event that was already recorded are skipped, and
+headers other than the signature and content type are never written to disk. When a handler bug
+is fixed, the captured records can be fed back through the same processing function. Replay verifies the signature
+against the secret but skips the timestamp check, since captured events are expected to be old.
+
+Usage example:
+
+	recorder, err := wordgate.OpenWebhookRecorder("webhooks.jsonl")
+	if err != nil {
+		log.Fatalf("Failed to open capture file: %v", err)
+	}
+	defer recorder.Close()
+
+	handler := wordgate.NewWebhookHandler("your_webhook_secret", process)
+	handler.Recorder = recorder
+	http.Handle("/webhook", handler)
+
+	// Later: replay the paid orders captured yesterday
+	file, _ := os.Open("webhooks.jsonl")
+	defer file.Close()
+
+	result, err := handler.Replay(context.Background(), file, &wordgate.WebhookReplayFilter{
+		EventTypes: []wordgate.WebhookEventType{wordgate.WebhookEventOrderPaid},
+		Since:      time.Now().AddDate(0, 0, -1),
+	})
+	if err != nil {
+		log.Fatalf("Failed to replay webhooks: %v", err)
+	}
+	log.Printf("Replayed %d of %d events, %d failed", result.Replayed, result.Total, len(result.Failures))
+*/
+package wordgate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// WebhookRecord 捕获的原始webhook请求
+type WebhookRecord struct {
+	ReceivedAt time.Time   `json:"received_at"` // 接收时间
+	Header     http.Header `json:"header"`      // 请求头
+	Body       string      `json:"body"`        // 请求体原文
+}
+
+// Signature 返回记录中的X-Webhook-Signature header值
+func (r *WebhookRecord) Signature() string {
+	return r.Header.Get(WebhookSignatureHeader)
+}
+
+// Event 解析记录中的webhook事件
+func (r *WebhookRecord) Event() (*WebhookEventData, error) {
+	var event WebhookEventData
+	if err := json.Unmarshal([]byte(r.Body), &event); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook record body: %w", err)
+	}
+	return &event, nil
+}
+
+// Verify 使用密钥验证记录的签名，不检查时间戳是否过期
+func (r *WebhookRecord) Verify(secret string) error {
+	webhookSig, err := ParseSignatureHeader(r.Signature())
+	if err != nil {
+		return fmt.Errorf("parse signature header failed: %w", err)
+	}
+	return verifySignatureDigest(webhookSig, []byte(r.Body), secret)
+}
+
+// recordedWebhookHeaders 记录时保留的请求头，其余请求头(如认证信息)不会写入磁盘
+// 时间戳包含在签名header中
+var recordedWebhookHeaders = []string{WebhookSignatureHeader, "Content-Type"}
+
+// WebhookRecorder 以JSON Lines格式记录原始webhook请求
+//
+// 同一事件(按Fingerprint判断)只记录一次，WordGate的重试投递不会重复写入
+type WebhookRecorder struct {
+	mu     sync.Mutex
+	writer io.Writer
+	closer io.Closer
+	seen   map[string]struct{}
+}
+
+// NewWebhookRecorder 创建写入指定writer的记录器
+func NewWebhookRecorder(w io.Writer) *WebhookRecorder {
+	return &WebhookRecorder{writer: w, seen: make(map[string]struct{})}
+}
+
+// OpenWebhookRecorder 打开(或创建)捕获文件并以追加方式记录
+//
+// 文件中已有的事件会被读入，之后重复投递的同一事件不会再次记录
+// path: 捕获文件路径
+func OpenWebhookRecorder(path string) (*WebhookRecorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open capture file: %w", err)
+	}
+
+	recorder := &WebhookRecorder{writer: file, closer: file, seen: make(map[string]struct{})}
+	err = ReadWebhookRecords(file, func(record *WebhookRecord) error {
+		recorder.seen[webhookRecordKey([]byte(record.Body))] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return recorder, nil
+}
+
+// Record 记录一次webhook请求，已记录过的事件会被忽略
+// header: 请求头，只保留签名和Content-Type
+// body: 请求体原文
+func (r *WebhookRecorder) Record(header http.Header, body []byte) error {
+	kept := make(http.Header)
+	for _, name := range recordedWebhookHeaders {
+		if values := header.Values(name); len(values) > 0 {
+			kept[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+		}
+	}
+
+	line, err := json.Marshal(WebhookRecord{
+		ReceivedAt: time.Now(),
+		Header:     kept,
+		Body:       string(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook record: %w", err)
+	}
+
+	key := webhookRecordKey(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.seen[key]; ok {
+		return nil
+	}
+	if _, err := r.writer.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write webhook record: %w", err)
+	}
+	r.seen[key] = struct{}{}
+	return nil
+}
+
+// webhookRecordKey 返回用于去重的事件摘要，无法解析的请求体使用原文摘要
+func webhookRecordKey(body []byte) string {
+	var event WebhookEventData
+	if err := json.Unmarshal(body, &event); err == nil {
+		if fingerprint, err := event.Fingerprint(); err == nil {
+			return fingerprint
+		}
+	}
+	sum := sha256.Sum256(bytes.TrimSpace(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// Close 关闭由OpenWebhookRecorder打开的文件
+func (r *WebhookRecorder) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// ReadWebhookRecords 逐条读取JSON Lines格式的捕获记录
+// r: 捕获文件内容
+// fn: 每条记录的回调，返回错误时停止读取
+func ReadWebhookRecords(r io.Reader, fn func(record *WebhookRecord) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxWebhookBodySize*2)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record WebhookRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("failed to parse webhook record on line %d: %w", line, err)
+		}
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read webhook records: %w", err)
+	}
+	return nil
+}
+
+// WebhookReplayFilter 回放时的过滤条件，零值表示不过滤
+type WebhookReplayFilter struct {
+	EventTypes []WebhookEventType // 事件类型
+	Since      time.Time          // 接收时间不早于
+	Until      time.Time          // 接收时间早于
+	OrderNo    string             // 订单号
+}
+
+// Match 判断记录是否满足过滤条件
+func (f *WebhookReplayFilter) Match(record *WebhookRecord, event *WebhookEventData) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.EventTypes) > 0 {
+		matched := false
+		for _, eventType := range f.EventTypes {
+			if event.EventType == eventType {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if !f.Since.IsZero() && record.ReceivedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !record.ReceivedAt.Before(f.Until) {
+		return false
+	}
+
+	if f.OrderNo != "" && webhookEventOrderNo(event) != f.OrderNo {
+		return false
+	}
+	return true
+}
+
+// WebhookReplayFailure 回放失败的记录
+type WebhookReplayFailure struct {
+	Record *WebhookRecord // 原始记录
+	Err    error          // 失败原因
+}
+
+// WebhookReplayResult 回放结果
+type WebhookReplayResult struct {
+	Total    int                    // 读取的记录数
+	Replayed int                    // 成功处理的记录数
+	Skipped  int                    // 不满足过滤条件的记录数
+	Failures []WebhookReplayFailure // 验签或处理失败的记录
+}
+
+// Replay 将捕获的记录交给处理器的Process函数重新处理
+//
+// 回放会使用处理器的Secret验证签名，但不检查时间戳是否过期；
+// 单条记录验签或处理失败不会中断回放，失败记录会出现在结果中
+// ctx: 传递给Process的上下文，取消后停止回放
+// r: 捕获文件内容
+// filter: 过滤条件(可选)
+func (h *WebhookHandler) Replay(ctx context.Context, r io.Reader, filter *WebhookReplayFilter) (*WebhookReplayResult, error) {
+	result := &WebhookReplayResult{}
+
+	err := ReadWebhookRecords(r, func(record *WebhookRecord) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		result.Total++
+
+		event, err := record.Event()
+		if err != nil {
+			result.Failures = append(result.Failures, WebhookReplayFailure{Record: record, Err: err})
+			return nil
+		}
+		if !filter.Match(record, event) {
+			result.Skipped++
+			return nil
+		}
+
+		err = record.Verify(h.Secret)
+		if err == nil && h.Process != nil {
+			err = h.Process(ctx, event)
+		}
+		if err != nil {
+			result.Failures = append(result.Failures, WebhookReplayFailure{Record: record, Err: err})
+			return nil
+		}
+
+		result.Replayed++
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to replay webhooks: %w", err)
+	}
+	return result, nil
+}
+
+// webhookEventOrderNo 返回事件数据中的订单号，没有订单号时返回空字符串
+func webhookEventOrderNo(event *WebhookEventData) string {
+	var data struct {
+		WordgateOrderNo string `json:"wordgate_order_no"`
+		OrderNo         string `json:"order_no"`
+	}
+	if err := event.Parse(&data); err != nil {
+		return ""
+	}
+	if data.WordgateOrderNo != "" {
+		return data.WordgateOrderNo
+	}
+	return data.OrderNo
+}
@@ -0,0 +1,90 @@
+package wordgate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func deliverWebhook(t *testing.T, handler http.Handler, secret string, body []byte) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer internal-token")
+	req.Header.Set(WebhookSignatureHeader, GenerateSignatureHeader(time.Now().Unix(), body, secret))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestWebhookHandlerRecordsFailedEventsForReplay(t *testing.T) {
+	const secret = "test_secret"
+	path := filepath.Join(t.TempDir(), "webhooks.jsonl")
+	recorder, err := OpenWebhookRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fail := true
+	var processed []string
+	handler := NewWebhookHandler(secret, func(ctx context.Context, event *WebhookEventData) error {
+		if fail {
+			return errors.New("handler bug")
+		}
+		processed = append(processed, string(event.EventType))
+		return nil
+	})
+	handler.Recorder = recorder
+
+	body := []byte(`{"event_type":"order.paid","app_id":1,"timestamp":1700000000,"data":{"order_no":"WG1"}}`)
+	for i := 0; i < 3; i++ {
+		if code := deliverWebhook(t, handler, secret, body); code != http.StatusInternalServerError {
+			t.Fatalf("failed delivery status = %d, want 500", code)
+		}
+	}
+	recorder.Close()
+
+	// Reopening keeps deduplicating against the existing file
+	recorder, err = OpenWebhookRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer recorder.Close()
+	handler.Recorder = recorder
+	deliverWebhook(t, handler, secret, body)
+
+	var records []*WebhookRecord
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ReadWebhookRecords(bytes.NewReader(data), func(record *WebhookRecord) error {
+		records = append(records, record)
+		return nil
+	})
+	if len(records) != 1 {
+		t.Fatalf("recorded %d events, want 1", len(records))
+	}
+	if records[0].Header.Get("Authorization") != "" {
+		t.Fatal("Authorization header was recorded")
+	}
+	if records[0].Signature() == "" || records[0].Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("recorded header = %v, want signature and content type", records[0].Header)
+	}
+
+	// After the bug is fixed the failed event can be replayed
+	fail = false
+	result, err := handler.Replay(context.Background(), bytes.NewReader(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Replayed != 1 || len(result.Failures) != 0 || len(processed) != 1 || processed[0] != "order.paid" {
+		t.Fatalf("Replay() = %+v, processed %v; want the failed event processed once", result, processed)
+	}
+}
@@ -0,0 +1,299 @@
+package wordgate
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WebhookDeliveryStatus represents the status of a webhook delivery attempt
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryStatusPending indicates the delivery is queued or awaiting retry
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryStatusSucceeded indicates the endpoint responded with HTTP 200
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryStatusFailed indicates the endpoint failed to respond with HTTP 200
+	WebhookDeliveryStatusFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookEndpoint represents a webhook endpoint registered for the application
+type WebhookEndpoint struct {
+	// ID is the unique identifier of the endpoint
+	ID uint64 `json:"id"`
+	// AppID is the application ID this endpoint belongs to
+	AppID uint64 `json:"app_id"`
+	// URL is the URL events are delivered to
+	URL string `json:"url"`
+	// Description is an optional description of the endpoint
+	Description string `json:"description"`
+	// EventTypes is the list of subscribed event types (empty means all events)
+	EventTypes []WebhookEventType `json:"event_types"`
+	// Enabled indicates whether events are delivered to this endpoint
+	Enabled bool `json:"enabled"`
+	// Secret is the signing secret (only returned when the endpoint is created)
+	Secret string `json:"secret,omitempty"`
+	// CreatedAt is the creation timestamp
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is the last update timestamp
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateWebhookEndpointRequest represents a request to register a webhook endpoint
+type CreateWebhookEndpointRequest struct {
+	// URL is the URL events are delivered to
+	URL string `json:"url" binding:"required,url"`
+	// Description is an optional description of the endpoint
+	Description string `json:"description,omitempty"`
+	// EventTypes is the list of subscribed event types (empty means all events)
+	EventTypes []WebhookEventType `json:"event_types,omitempty"`
+	// Enabled indicates whether events are delivered to this endpoint
+	Enabled bool `json:"enabled"`
+}
+
+// UpdateWebhookEndpointRequest represents a request to update a webhook endpoint
+type UpdateWebhookEndpointRequest struct {
+	// URL is the URL events are delivered to
+	URL string `json:"url" binding:"required,url"`
+	// Description is an optional description of the endpoint
+	Description string `json:"description"`
+	// EventTypes is the list of subscribed event types (empty means all events)
+	EventTypes []WebhookEventType `json:"event_types"`
+	// Enabled indicates whether events are delivered to this endpoint
+	Enabled bool `json:"enabled"`
+}
+
+// ListWebhookEndpointsRequest represents a request to list webhook endpoints
+type ListWebhookEndpointsRequest struct {
+	// Page is the page number (starting from 1)
+	Page int `json:"page,omitempty"`
+	// Limit is the number of items per page
+	Limit int `json:"limit,omitempty"`
+}
+
+// WebhookEndpointListResponse represents a paginated list of webhook endpoints
+type WebhookEndpointListResponse struct {
+	// Data is the list of webhook endpoints
+	Data []WebhookEndpoint `json:"data"`
+	// Pagination contains pagination information
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// RotateWebhookSecretRequest represents a request to rotate an endpoint's signing secret
+type RotateWebhookSecretRequest struct {
+	// GracePeriodSeconds keeps the previous secret valid for this many seconds (optional)
+	GracePeriodSeconds int `json:"grace_period_seconds,omitempty"`
+}
+
+// RotateWebhookSecretResponse represents the response from rotating a signing secret
+type RotateWebhookSecretResponse struct {
+	// Secret is the new signing secret
+	Secret string `json:"secret"`
+	// PreviousSecretExpiresAt is when the previous secret stops being used (nil if immediately)
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at"`
+}
+
+// WebhookDelivery represents a single delivery attempt of an event to an endpoint
+type WebhookDelivery struct {
+	// ID is the unique identifier of the delivery
+	ID uint64 `json:"id"`
+	// EndpointID is the endpoint the event was delivered to
+	EndpointID uint64 `json:"endpoint_id"`
+	// EventType is the delivered event type
+	EventType WebhookEventType `json:"event_type"`
+	// Status is the delivery status
+	Status WebhookDeliveryStatus `json:"status"`
+	// StatusCode is the HTTP status code returned by the endpoint (0 if no response)
+	StatusCode int `json:"status_code"`
+	// Attempt is the attempt number, starting from 1
+	Attempt int `json:"attempt"`
+	// Error is the error message for failed deliveries
+	Error string `json:"error,omitempty"`
+	// DurationMs is the request duration in milliseconds
+	DurationMs int64 `json:"duration_ms"`
+	// Payload is the delivered request body
+	Payload string `json:"payload,omitempty"`
+	// CreatedAt is the delivery timestamp
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListWebhookDeliveriesRequest represents a request to list delivery attempts of an endpoint
+type ListWebhookDeliveriesRequest struct {
+	// Status filters deliveries by status (optional)
+	Status WebhookDeliveryStatus `json:"status,omitempty"`
+	// EventType filters deliveries by event type (optional)
+	EventType WebhookEventType `json:"event_type,omitempty"`
+	// Page is the page number (starting from 1)
+	Page int `json:"page,omitempty"`
+	// Limit is the number of items per page
+	Limit int `json:"limit,omitempty"`
+}
+
+// WebhookDeliveryListResponse represents a paginated list of delivery attempts
+type WebhookDeliveryListResponse struct {
+	// Data is the list of delivery attempts, newest first
+	Data []WebhookDelivery `json:"data"`
+	// Pagination contains pagination information
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// CreateWebhookEndpoint registers a new webhook endpoint
+//
+// request: The endpoint creation request containing URL and subscribed events
+// Returns the created endpoint including its signing secret and any error
+func (c *Client) CreateWebhookEndpoint(request *CreateWebhookEndpointRequest) (*WebhookEndpoint, error) {
+	var result WebhookEndpoint
+	err := c.requestJSON("POST", "/app/webhooks/endpoints", request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+	return &result, nil
+}
+
+// GetWebhookEndpoint retrieves webhook endpoint details by ID
+//
+// id: The endpoint ID to retrieve
+// Returns the endpoint details and any error
+func (c *Client) GetWebhookEndpoint(id uint64) (*WebhookEndpoint, error) {
+	var result WebhookEndpoint
+	path := fmt.Sprintf("/app/webhooks/endpoints/%d", id)
+	err := c.requestJSON("GET", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+	return &result, nil
+}
+
+// UpdateWebhookEndpoint updates an existing webhook endpoint
+//
+// id: The endpoint ID to update
+// request: The endpoint update request containing URL and subscribed events
+// Returns the updated endpoint and any error
+func (c *Client) UpdateWebhookEndpoint(id uint64, request *UpdateWebhookEndpointRequest) (*WebhookEndpoint, error) {
+	var result WebhookEndpoint
+	path := fmt.Sprintf("/app/webhooks/endpoints/%d", id)
+	err := c.requestJSON("PUT", path, request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+	return &result, nil
+}
+
+// DeleteWebhookEndpoint deletes a webhook endpoint by ID
+//
+// id: The endpoint ID to delete
+// Returns any error encountered during deletion
+func (c *Client) DeleteWebhookEndpoint(id uint64) error {
+	var result map[string]interface{}
+	path := fmt.Sprintf("/app/webhooks/endpoints/%d", id)
+	err := c.requestJSON("DELETE", path, nil, &result)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// ListWebhookEndpoints retrieves a paginated list of webhook endpoints
+//
+// request: The list request containing pagination parameters
+// Returns the endpoint list with pagination information and any error
+func (c *Client) ListWebhookEndpoints(request *ListWebhookEndpointsRequest) (*WebhookEndpointListResponse, error) {
+	// Build query parameters
+	params := url.Values{}
+
+	if request != nil {
+		if request.Page > 0 {
+			params.Set("page", strconv.Itoa(request.Page))
+		}
+		if request.Limit > 0 {
+			params.Set("limit", strconv.Itoa(request.Limit))
+		}
+	}
+
+	// Build path with query parameters
+	path := "/app/webhooks/endpoints"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var result WebhookEndpointListResponse
+	err := c.requestJSON("GET", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	return &result, nil
+}
+
+// RotateWebhookEndpointSecret generates a new signing secret for a webhook endpoint
+//
+// id: The endpoint ID to rotate the secret for
+// request: The rotation request (optional, nil rotates immediately)
+// Returns the new secret and any error
+func (c *Client) RotateWebhookEndpointSecret(id uint64, request *RotateWebhookSecretRequest) (*RotateWebhookSecretResponse, error) {
+	if request == nil {
+		request = &RotateWebhookSecretRequest{}
+	}
+
+	var result RotateWebhookSecretResponse
+	path := fmt.Sprintf("/app/webhooks/endpoints/%d/rotate-secret", id)
+	err := c.requestJSON("POST", path, request, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate webhook endpoint secret: %w", err)
+	}
+	return &result, nil
+}
+
+// ListWebhookDeliveries retrieves recent delivery attempts of a webhook endpoint
+//
+// endpointID: The endpoint ID to list deliveries for
+// request: The list request containing filter and pagination parameters
+// Returns the delivery list with pagination information and any error
+func (c *Client) ListWebhookDeliveries(endpointID uint64, request *ListWebhookDeliveriesRequest) (*WebhookDeliveryListResponse, error) {
+	// Build query parameters
+	params := url.Values{}
+
+	if request != nil {
+		if request.Status != "" {
+			params.Set("status", string(request.Status))
+		}
+		if request.EventType != "" {
+			params.Set("event_type", string(request.EventType))
+		}
+		if request.Page > 0 {
+			params.Set("page", strconv.Itoa(request.Page))
+		}
+		if request.Limit > 0 {
+			params.Set("limit", strconv.Itoa(request.Limit))
+		}
+	}
+
+	// Build path with query parameters
+	path := fmt.Sprintf("/app/webhooks/endpoints/%d/deliveries", endpointID)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var result WebhookDeliveryListResponse
+	err := c.requestJSON("GET", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return &result, nil
+}
+
+// RedeliverWebhookDelivery triggers redelivery of a previous delivery attempt
+//
+// endpointID: The endpoint ID the delivery belongs to
+// deliveryID: The delivery ID to redeliver
+// Returns the new delivery attempt and any error
+func (c *Client) RedeliverWebhookDelivery(endpointID, deliveryID uint64) (*WebhookDelivery, error) {
+	var result WebhookDelivery
+	path := fmt.Sprintf("/app/webhooks/endpoints/%d/deliveries/%d/redeliver", endpointID, deliveryID)
+	err := c.requestJSON("POST", path, nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeliver webhook: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,108 @@
+package wordgate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultWebhookMaxTimeDiff 默认允许的签名时间差(秒)
+const DefaultWebhookMaxTimeDiff int64 = 300
+
+// maxWebhookBodySize webhook请求体的最大字节数
+const maxWebhookBodySize = 1 << 20
+
+// WebhookProcessFunc 处理已验签的webhook事件，返回错误表示处理失败
+type WebhookProcessFunc func(ctx context.Context, event *WebhookEventData) error
+
+// WebhookHandler 验证签名并将事件交给处理函数的http.Handler
+//
+// 返回状态码遵循webhook.md的约定: 400 格式错误，401 签名验证失败，408 时间戳过期，
+// 处理函数返回错误时返回500以便WordGate重试
+type WebhookHandler struct {
+	// Secret 签名密钥
+	Secret string
+	// MaxTimeDiff 最大时间差(秒)，用于防重放攻击
+	MaxTimeDiff int64
+	// Process 事件处理函数
+	Process WebhookProcessFunc
+	// Recorder 记录验签通过的原始请求(可选)，包括处理失败的请求，用于之后回放
+	Recorder *WebhookRecorder
+}
+
+// NewWebhookHandler 创建webhook处理器
+// secret: 签名密钥
+// process: 事件处理函数
+func NewWebhookHandler(secret string, process WebhookProcessFunc) *WebhookHandler {
+	return &WebhookHandler{
+		Secret:      secret,
+		MaxTimeDiff: DefaultWebhookMaxTimeDiff,
+		Process:     process,
+	}
+}
+
+// ServeHTTP 实现http.Handler接口
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	// 验证签名
+	if err := VerifySignature(r.Header.Get(WebhookSignatureHeader), body, h.Secret, h.MaxTimeDiff); err != nil {
+		switch {
+		case errors.Is(err, ErrWebhookSignatureMismatch):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case errors.Is(err, ErrWebhookTimestampTooOld):
+			http.Error(w, err.Error(), http.StatusRequestTimeout)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	var event WebhookEventData
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "invalid event payload", http.StatusBadRequest)
+		return
+	}
+
+	// 在处理之前记录，处理失败的事件也能在修复后回放；重复投递由Recorder去重
+	if h.Recorder != nil {
+		if err := h.Recorder.Record(r.Header, body); err != nil {
+			http.Error(w, "failed to record event", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if h.Process != nil {
+		if err := h.Process(r.Context(), &event); err != nil {
+			http.Error(w, "failed to process event", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Fingerprint 返回事件内容的SHA-256摘要，同一事件的重复投递具有相同的摘要
+func (w *WebhookEventData) Fingerprint() (string, error) {
+	jsonBytes, err := json.Marshal(w)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	sum := sha256.Sum256(jsonBytes)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,714 @@
+/*
+Durable webhook inbox for WordGate webhook events.
+
+The inbox decouples receiving webhooks from processing them: the HTTP handler verifies the
+signature, persists the event to an InboxStore and acknowledges immediately, while a pool of
+workers processes stored events with retries and exponential backoff. Events that keep failing
+are moved to a dead-letter queue where they can be inspected and replayed.
+
+Usage example:
+
+	store, err := wordgate.NewFileInboxStore("/var/lib/myapp/webhook-inbox.jsonl")
+	if err != nil {
+		log.Fatalf("Failed to open inbox store: %v", err)
+	}
+	defer store.Close()
+
+	inbox := wordgate.NewWebhookInbox(store, func(ctx context.Context, event *wordgate.WebhookEventData) error {
+		// Slow work such as provisioning or sending emails
+		return provision(ctx, event)
+	})
+	inbox.Workers = 8
+
+	if err := inbox.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start inbox: %v", err)
+	}
+	defer inbox.Stop()
+
+	http.Handle("/webhook", inbox.Handler("your_webhook_secret"))
+
+	// Inspect and replay dead letters
+	deadLetters, _ := inbox.DeadLetters()
+	for _, entry := range deadLetters {
+		log.Printf("Event %s (%s) failed: %s", entry.ID, entry.Event.EventType, entry.LastError)
+		inbox.Replay(entry.ID)
+	}
+*/
+package wordgate
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// InboxStatus inbox中事件的处理状态
+type InboxStatus string
+
+const (
+	InboxStatusPending    InboxStatus = "pending"    // 等待处理(含等待重试)
+	InboxStatusProcessing InboxStatus = "processing" // 处理中
+	InboxStatusDone       InboxStatus = "done"       // 处理成功
+	InboxStatusDead       InboxStatus = "dead"       // 超过最大重试次数，进入死信队列
+)
+
+// ErrInboxEntryNotFound inbox中不存在指定事件
+var ErrInboxEntryNotFound = errors.New("inbox entry not found")
+
+// ErrInboxCompactionFailed 记录已写入，但压缩存储文件失败；下次写入时会再次尝试压缩
+var ErrInboxCompactionFailed = errors.New("inbox compaction failed")
+
+const (
+	// DefaultInboxCompactAfter 文件inbox存储默认每追加多少行压缩一次
+	DefaultInboxCompactAfter = 1000
+	// DefaultInboxDoneRetention 文件inbox存储默认保留已完成事件的时长
+	DefaultInboxDoneRetention = 7 * 24 * time.Hour
+)
+
+// InboxEntry inbox中保存的事件记录
+type InboxEntry struct {
+	ID            string           `json:"id"`              // 事件摘要，见 WebhookEventData.Fingerprint
+	Event         WebhookEventData `json:"event"`           // 事件数据
+	Status        InboxStatus      `json:"status"`          // 处理状态
+	Attempts      int              `json:"attempts"`        // 已尝试处理次数
+	LastError     string           `json:"last_error"`      // 最近一次处理失败的原因
+	ReceivedAt    time.Time        `json:"received_at"`     // 接收时间
+	NextAttemptAt time.Time        `json:"next_attempt_at"` // 下次可处理时间
+	UpdatedAt     time.Time        `json:"updated_at"`      // 最近更新时间
+}
+
+// InboxStore inbox事件的持久化存储
+type InboxStore interface {
+	// Put 保存或覆盖事件记录
+	Put(entry InboxEntry) error
+	// Get 按ID获取事件记录，不存在时返回 ErrInboxEntryNotFound
+	Get(id string) (InboxEntry, error)
+	// List 返回指定状态的事件记录，按接收时间排序
+	List(status InboxStatus) ([]InboxEntry, error)
+}
+
+// MemoryInboxStore 基于内存的inbox存储，进程退出后数据丢失
+type MemoryInboxStore struct {
+	mu      sync.RWMutex
+	entries map[string]InboxEntry
+}
+
+// NewMemoryInboxStore 创建内存inbox存储
+func NewMemoryInboxStore() *MemoryInboxStore {
+	return &MemoryInboxStore{
+		entries: make(map[string]InboxEntry),
+	}
+}
+
+// Put 保存或覆盖事件记录
+func (s *MemoryInboxStore) Put(entry InboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entry.ID] = entry
+	return nil
+}
+
+// Get 按ID获取事件记录
+func (s *MemoryInboxStore) Get(id string) (InboxEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[id]
+	if !ok {
+		return InboxEntry{}, ErrInboxEntryNotFound
+	}
+	return entry, nil
+}
+
+// List 返回指定状态的事件记录，按接收时间排序
+func (s *MemoryInboxStore) List(status InboxStatus) ([]InboxEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var entries []InboxEntry
+	for _, entry := range s.entries {
+		if entry.Status == status {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ReceivedAt.Equal(entries[j].ReceivedAt) {
+			return entries[i].ID < entries[j].ID
+		}
+		return entries[i].ReceivedAt.Before(entries[j].ReceivedAt)
+	})
+	return entries, nil
+}
+
+// FileInboxStore 基于追加写文件(JSON Lines)的inbox存储
+//
+// 每次Put都会追加一行记录并同步到磁盘，打开文件时按顺序回放，同一ID以最后一行为准。
+// 打开文件时以及每追加CompactAfter行后，文件会被压缩为每个事件一行(写入临时文件后原子重命名)，
+// 并删除完成时间早于DoneRetention的事件；删除后同一事件的重复投递会被重新处理。
+type FileInboxStore struct {
+	// CompactAfter 追加多少行后压缩文件(<=0时不自动压缩)
+	CompactAfter int
+	// DoneRetention 已完成事件的保留时长(<=0时永久保留)
+	DoneRetention time.Duration
+
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	size    int64
+	appends int
+	memory  *MemoryInboxStore
+}
+
+// NewFileInboxStore 打开或创建文件inbox存储
+// path: 存储文件路径
+func NewFileInboxStore(path string) (*FileInboxStore, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open inbox file: %w", err)
+	}
+
+	store := &FileInboxStore{
+		CompactAfter:  DefaultInboxCompactAfter,
+		DoneRetention: DefaultInboxDoneRetention,
+		path:          path,
+		file:          file,
+		memory:        NewMemoryInboxStore(),
+	}
+
+	// 回放已有记录
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxWebhookBodySize*2)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry InboxEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to parse inbox file: %w", err)
+		}
+		store.memory.Put(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read inbox file: %w", err)
+	}
+
+	if err := store.compact(); err != nil {
+		store.file.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Put 追加事件记录到文件，写入失败时文件和内存中的记录都保持不变
+//
+// 记录已写入但压缩失败时返回 ErrInboxCompactionFailed
+func (s *FileInboxStore) Put(entry InboxEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal inbox entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(line); err != nil {
+		// 截掉可能写入的半行，避免下次打开时无法解析
+		s.file.Truncate(s.size)
+		return fmt.Errorf("failed to write inbox entry: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		s.file.Truncate(s.size)
+		return fmt.Errorf("failed to sync inbox file: %w", err)
+	}
+	s.size += int64(len(line))
+	s.appends++
+	s.memory.Put(entry)
+
+	if s.CompactAfter > 0 && s.appends >= s.CompactAfter {
+		if err := s.compact(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInboxCompactionFailed, err)
+		}
+	}
+	return nil
+}
+
+// Compact 将文件重写为每个事件一行，并删除超过保留时长的已完成事件
+func (s *FileInboxStore) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.compact()
+}
+
+// compact 压缩文件，调用方需持有s.mu
+func (s *FileInboxStore) compact() error {
+	s.memory.mu.RLock()
+	var keep []InboxEntry
+	var expired []string
+	cutoff := time.Now().Add(-s.DoneRetention)
+	for id, entry := range s.memory.entries {
+		if s.DoneRetention > 0 && entry.Status == InboxStatusDone && entry.UpdatedAt.Before(cutoff) {
+			expired = append(expired, id)
+			continue
+		}
+		keep = append(keep, entry)
+	}
+	s.memory.mu.RUnlock()
+	sort.Slice(keep, func(i, j int) bool {
+		if keep[i].ReceivedAt.Equal(keep[j].ReceivedAt) {
+			return keep[i].ID < keep[j].ID
+		}
+		return keep[i].ReceivedAt.Before(keep[j].ReceivedAt)
+	})
+
+	// 写入临时文件后重命名，任何一步失败时原文件保持不变
+	tmpPath := s.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create compacted inbox file: %w", err)
+	}
+	writer := bufio.NewWriter(tmp)
+	var size int64
+	for _, entry := range keep {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return fmt.Errorf("failed to marshal inbox entry: %w", err)
+		}
+		writer.Write(line)
+		writer.WriteByte('\n')
+		size += int64(len(line)) + 1
+	}
+	if err := writer.Flush(); err == nil {
+		err = tmp.Sync()
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write compacted inbox file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write compacted inbox file: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace inbox file: %w", err)
+	}
+	if dir, err := os.Open(filepath.Dir(s.path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+
+	file, err := os.OpenFile(s.path, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to reopen inbox file: %w", err)
+	}
+	s.file.Close()
+	s.file = file
+	s.size = size
+	s.appends = 0
+
+	s.memory.mu.Lock()
+	for _, id := range expired {
+		delete(s.memory.entries, id)
+	}
+	s.memory.mu.Unlock()
+	return nil
+}
+
+// Get 按ID获取事件记录
+func (s *FileInboxStore) Get(id string) (InboxEntry, error) {
+	return s.memory.Get(id)
+}
+
+// List 返回指定状态的事件记录，按接收时间排序
+func (s *FileInboxStore) List(status InboxStatus) ([]InboxEntry, error) {
+	return s.memory.List(status)
+}
+
+// Close 关闭存储文件
+func (s *FileInboxStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// WebhookInbox 持久化webhook事件并异步处理的收件箱
+type WebhookInbox struct {
+	// Store 事件存储
+	Store InboxStore
+	// Process 事件处理函数
+	Process WebhookProcessFunc
+	// Workers 并发处理的worker数量
+	Workers int
+	// MaxAttempts 最大处理次数，超过后进入死信队列
+	MaxAttempts int
+	// InitialBackoff 首次重试的等待时间，之后每次翻倍
+	InitialBackoff time.Duration
+	// MaxBackoff 重试等待时间上限
+	MaxBackoff time.Duration
+	// PollInterval 检查待处理事件的间隔
+	PollInterval time.Duration
+	// OnError 存储读写失败时调用(可选)；写入失败的状态会在之后的调度中重试写入，
+	// 在写入成功前事件不会被视为已确认，进程重启后会被重新处理
+	OnError func(err error)
+
+	mu      sync.Mutex
+	wake    chan struct{}
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+	unsaved map[string]InboxEntry
+}
+
+// NewWebhookInbox 创建webhook收件箱
+// store: 事件存储
+// process: 事件处理函数
+func NewWebhookInbox(store InboxStore, process WebhookProcessFunc) *WebhookInbox {
+	return &WebhookInbox{
+		Store:          store,
+		Process:        process,
+		Workers:        4,
+		MaxAttempts:    8,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Hour,
+		PollInterval:   time.Second,
+		wake:           make(chan struct{}, 1),
+	}
+}
+
+// Handler 返回验签后将事件写入收件箱并立即确认的http.Handler
+// secret: 签名密钥
+func (i *WebhookInbox) Handler(secret string) *WebhookHandler {
+	return NewWebhookHandler(secret, i.Enqueue)
+}
+
+// Enqueue 将事件写入收件箱，已存在的事件(重复投递)会被忽略
+func (i *WebhookInbox) Enqueue(ctx context.Context, event *WebhookEventData) error {
+	id, err := event.Fingerprint()
+	if err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if _, err := i.Store.Get(id); err == nil {
+		return nil
+	} else if !errors.Is(err, ErrInboxEntryNotFound) {
+		return fmt.Errorf("failed to check inbox entry: %w", err)
+	}
+
+	now := time.Now()
+	entry := InboxEntry{
+		ID:            id,
+		Event:         *event,
+		Status:        InboxStatusPending,
+		ReceivedAt:    now,
+		NextAttemptAt: now,
+		UpdatedAt:     now,
+	}
+	if err := i.put(entry); err != nil {
+		return fmt.Errorf("failed to store inbox entry: %w", err)
+	}
+
+	i.notify()
+	return nil
+}
+
+// Start 启动后台worker，处理中断时遗留的processing状态事件会重新排队
+func (i *WebhookInbox) Start(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.cancel != nil {
+		return fmt.Errorf("webhook inbox already started")
+	}
+	if i.wake == nil {
+		i.wake = make(chan struct{}, 1)
+	}
+
+	// 恢复上次中断时正在处理的事件
+	processing, err := i.Store.List(InboxStatusProcessing)
+	if err != nil {
+		return fmt.Errorf("failed to list processing entries: %w", err)
+	}
+	for _, entry := range processing {
+		entry.Status = InboxStatusPending
+		entry.UpdatedAt = time.Now()
+		if err := i.put(entry); err != nil {
+			return fmt.Errorf("failed to requeue inbox entry: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	i.cancel = cancel
+
+	workers := i.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	jobs := make(chan InboxEntry)
+
+	i.running.Add(workers + 1)
+	go i.dispatch(ctx, jobs)
+	for w := 0; w < workers; w++ {
+		go i.work(ctx, jobs)
+	}
+	return nil
+}
+
+// Stop 停止后台worker并等待正在处理的事件完成
+func (i *WebhookInbox) Stop() {
+	i.mu.Lock()
+	cancel := i.cancel
+	i.cancel = nil
+	i.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		i.running.Wait()
+	}
+}
+
+// DeadLetters 返回死信队列中的事件
+func (i *WebhookInbox) DeadLetters() ([]InboxEntry, error) {
+	return i.Store.List(InboxStatusDead)
+}
+
+// Replay 将死信队列中的事件重新排队，重置重试次数
+// id: 事件ID
+func (i *WebhookInbox) Replay(id string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	entry, err := i.Store.Get(id)
+	if err != nil {
+		return err
+	}
+	if entry.Status != InboxStatusDead {
+		return fmt.Errorf("inbox entry %s is %s, not dead", id, entry.Status)
+	}
+
+	now := time.Now()
+	entry.Status = InboxStatusPending
+	entry.Attempts = 0
+	entry.NextAttemptAt = now
+	entry.UpdatedAt = now
+	if err := i.put(entry); err != nil {
+		return fmt.Errorf("failed to requeue inbox entry: %w", err)
+	}
+
+	i.notify()
+	return nil
+}
+
+// ReplayAll 将死信队列中的全部事件重新排队，返回重新排队的数量
+func (i *WebhookInbox) ReplayAll() (int, error) {
+	deadLetters, err := i.DeadLetters()
+	if err != nil {
+		return 0, err
+	}
+
+	for n, entry := range deadLetters {
+		if err := i.Replay(entry.ID); err != nil {
+			return n, err
+		}
+	}
+	return len(deadLetters), nil
+}
+
+// dispatch 定期取出到期的待处理事件并交给worker
+func (i *WebhookInbox) dispatch(ctx context.Context, jobs chan<- InboxEntry) {
+	defer i.running.Done()
+	defer close(jobs)
+
+	interval := i.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		i.flushUnsaved()
+		claimed := i.claimDue()
+		for n, entry := range claimed {
+			select {
+			case jobs <- entry:
+			case <-ctx.Done():
+				i.release(claimed[n:])
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-i.wake:
+		}
+	}
+}
+
+// claimDue 将到期的待处理事件标记为processing并返回
+func (i *WebhookInbox) claimDue() []InboxEntry {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	pending, err := i.Store.List(InboxStatusPending)
+	if err != nil {
+		i.reportError(fmt.Errorf("failed to list pending inbox entries: %w", err))
+		return nil
+	}
+
+	now := time.Now()
+	var claimed []InboxEntry
+	for _, entry := range pending {
+		if entry.NextAttemptAt.After(now) {
+			continue
+		}
+		entry.Status = InboxStatusProcessing
+		entry.UpdatedAt = now
+		if err := i.put(entry); err != nil {
+			// 未能标记为processing，事件保持pending状态，下次调度时重试
+			i.reportError(fmt.Errorf("failed to claim inbox entry %s: %w", entry.ID, err))
+			continue
+		}
+		claimed = append(claimed, entry)
+	}
+	return claimed
+}
+
+// release 将未交给worker的事件放回待处理状态
+func (i *WebhookInbox) release(entries []InboxEntry) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, entry := range entries {
+		entry.Status = InboxStatusPending
+		entry.UpdatedAt = time.Now()
+		i.save(entry)
+	}
+}
+
+// work 处理事件并根据结果更新状态
+func (i *WebhookInbox) work(ctx context.Context, jobs <-chan InboxEntry) {
+	defer i.running.Done()
+
+	for entry := range jobs {
+		err := i.process(ctx, &entry)
+
+		i.mu.Lock()
+		now := time.Now()
+		entry.Attempts++
+		entry.UpdatedAt = now
+		switch {
+		case err == nil:
+			entry.Status = InboxStatusDone
+			entry.LastError = ""
+		case ctx.Err() != nil:
+			// 关闭过程中被中断，不计入重试次数
+			entry.Attempts--
+			entry.Status = InboxStatusPending
+			entry.LastError = err.Error()
+		case i.MaxAttempts > 0 && entry.Attempts >= i.MaxAttempts:
+			entry.Status = InboxStatusDead
+			entry.LastError = err.Error()
+		default:
+			entry.Status = InboxStatusPending
+			entry.LastError = err.Error()
+			entry.NextAttemptAt = now.Add(i.backoff(entry.Attempts))
+		}
+		i.save(entry)
+		i.mu.Unlock()
+	}
+}
+
+// process 调用处理函数，并将panic转换为错误
+func (i *WebhookInbox) process(ctx context.Context, entry *InboxEntry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while processing event: %v", r)
+		}
+	}()
+
+	if i.Process == nil {
+		return nil
+	}
+	return i.Process(ctx, &entry.Event)
+}
+
+// backoff 返回第attempts次失败后的重试等待时间
+func (i *WebhookInbox) backoff(attempts int) time.Duration {
+	delay := i.InitialBackoff
+	if delay <= 0 {
+		delay = time.Second
+	}
+	for n := 1; n < attempts; n++ {
+		delay *= 2
+		if i.MaxBackoff > 0 && delay >= i.MaxBackoff {
+			return i.MaxBackoff
+		}
+	}
+	if i.MaxBackoff > 0 && delay > i.MaxBackoff {
+		return i.MaxBackoff
+	}
+	return delay
+}
+
+// put 保存事件记录，记录已写入但压缩失败时只报告错误
+func (i *WebhookInbox) put(entry InboxEntry) error {
+	err := i.Store.Put(entry)
+	if errors.Is(err, ErrInboxCompactionFailed) {
+		i.reportError(err)
+		return nil
+	}
+	return err
+}
+
+// save 保存状态变更，失败时报告错误并留待flushUnsaved重试，调用方需持有i.mu
+func (i *WebhookInbox) save(entry InboxEntry) {
+	if err := i.put(entry); err != nil {
+		i.reportError(fmt.Errorf("failed to save inbox entry %s as %s: %w", entry.ID, entry.Status, err))
+		if i.unsaved == nil {
+			i.unsaved = make(map[string]InboxEntry)
+		}
+		i.unsaved[entry.ID] = entry
+		return
+	}
+	delete(i.unsaved, entry.ID)
+}
+
+// flushUnsaved 重试写入之前保存失败的状态变更
+func (i *WebhookInbox) flushUnsaved() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, entry := range i.unsaved {
+		i.save(entry)
+	}
+}
+
+// reportError 将错误交给OnError
+func (i *WebhookInbox) reportError(err error) {
+	if i.OnError != nil {
+		i.OnError(err)
+	}
+}
+
+// notify 唤醒调度循环
+func (i *WebhookInbox) notify() {
+	select {
+	case i.wake <- struct{}{}:
+	default:
+	}
+}
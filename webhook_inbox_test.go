@@ -0,0 +1,232 @@
+package wordgate
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testInboxEntry(id string, status InboxStatus, updatedAt time.Time) InboxEntry {
+	return InboxEntry{
+		ID:         id,
+		Event:      WebhookEventData{EventType: WebhookEventOrderPaid, AppID: 1, Timestamp: updatedAt.Unix()},
+		Status:     status,
+		ReceivedAt: updatedAt,
+		UpdatedAt:  updatedAt,
+	}
+}
+
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines
+}
+
+func TestFileInboxStoreReplaysLastState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inbox.jsonl")
+	store, err := NewFileInboxStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	store.Put(testInboxEntry("a", InboxStatusPending, now))
+	store.Put(testInboxEntry("a", InboxStatusProcessing, now))
+	store.Put(testInboxEntry("b", InboxStatusDead, now))
+	store.Close()
+
+	reopened, err := NewFileInboxStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+
+	entry, err := reopened.Get("a")
+	if err != nil || entry.Status != InboxStatusProcessing {
+		t.Fatalf("Get(a) = %v, %v; want processing", entry.Status, err)
+	}
+	dead, _ := reopened.List(InboxStatusDead)
+	if len(dead) != 1 || dead[0].ID != "b" {
+		t.Fatalf("dead entries = %v, want [b]", dead)
+	}
+	if lines := countLines(t, path); lines != 2 {
+		t.Fatalf("file has %d lines after compaction on open, want 2", lines)
+	}
+}
+
+func TestFileInboxStoreCompactsAfterAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inbox.jsonl")
+	store, err := NewFileInboxStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	store.CompactAfter = 4
+
+	now := time.Now()
+	for _, status := range []InboxStatus{InboxStatusPending, InboxStatusProcessing, InboxStatusPending, InboxStatusProcessing} {
+		if err := store.Put(testInboxEntry("a", status, now)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if lines := countLines(t, path); lines != 1 {
+		t.Fatalf("file has %d lines, want 1", lines)
+	}
+
+	// Appends after compaction go to the new file
+	store.Put(testInboxEntry("b", InboxStatusPending, now))
+	if lines := countLines(t, path); lines != 2 {
+		t.Fatalf("file has %d lines, want 2", lines)
+	}
+}
+
+func TestFileInboxStoreDropsExpiredDoneEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inbox.jsonl")
+	store, err := NewFileInboxStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * DefaultInboxDoneRetention)
+	store.Put(testInboxEntry("old-done", InboxStatusDone, old))
+	store.Put(testInboxEntry("old-dead", InboxStatusDead, old))
+	store.Put(testInboxEntry("new-done", InboxStatusDone, time.Now()))
+	if err := store.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	reopened, err := NewFileInboxStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if _, err := reopened.Get("old-done"); !errors.Is(err, ErrInboxEntryNotFound) {
+		t.Fatalf("Get(old-done) error = %v, want ErrInboxEntryNotFound", err)
+	}
+	for _, id := range []string{"old-dead", "new-done"} {
+		if _, err := reopened.Get(id); err != nil {
+			t.Fatalf("Get(%s) error = %v", id, err)
+		}
+	}
+}
+
+// flakyInboxStore fails Put for entries with a given status until healed
+type flakyInboxStore struct {
+	*MemoryInboxStore
+	mu       sync.Mutex
+	failOn   InboxStatus
+	failures int
+}
+
+func (s *flakyInboxStore) Put(entry InboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry.Status == s.failOn && s.failures > 0 {
+		s.failures--
+		return errors.New("disk full")
+	}
+	return s.MemoryInboxStore.Put(entry)
+}
+
+func waitForStatus(t *testing.T, store InboxStore, id string, status InboxStatus) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if entry, err := store.Get(id); err == nil && entry.Status == status {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	entry, _ := store.Get(id)
+	t.Fatalf("entry %s is %s, want %s", id, entry.Status, status)
+}
+
+func TestWebhookInboxRetriesFailedStateWrites(t *testing.T) {
+	store := &flakyInboxStore{MemoryInboxStore: NewMemoryInboxStore(), failOn: InboxStatusDone, failures: 2}
+	var mu sync.Mutex
+	var reported []error
+	processed := make(chan struct{}, 10)
+
+	inbox := NewWebhookInbox(store, func(ctx context.Context, event *WebhookEventData) error {
+		processed <- struct{}{}
+		return nil
+	})
+	inbox.PollInterval = 5 * time.Millisecond
+	inbox.OnError = func(err error) {
+		mu.Lock()
+		reported = append(reported, err)
+		mu.Unlock()
+	}
+
+	event := &WebhookEventData{EventType: WebhookEventOrderPaid, AppID: 1, Timestamp: 1}
+	if err := inbox.Enqueue(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	id, _ := event.Fingerprint()
+	if err := inbox.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer inbox.Stop()
+
+	waitForStatus(t, store, id, InboxStatusDone)
+	if len(processed) != 1 {
+		t.Fatalf("processed %d times, want 1", len(processed))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) != 2 {
+		t.Fatalf("reported %d errors, want 2: %v", len(reported), reported)
+	}
+}
+
+func TestWebhookInboxDeadLetterReplay(t *testing.T) {
+	store := NewMemoryInboxStore()
+	var mu sync.Mutex
+	fail := true
+	inbox := NewWebhookInbox(store, func(ctx context.Context, event *WebhookEventData) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if fail {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	})
+	inbox.PollInterval = 5 * time.Millisecond
+	inbox.InitialBackoff = time.Millisecond
+	inbox.MaxAttempts = 2
+
+	event := &WebhookEventData{EventType: WebhookEventOrderPaid, AppID: 1, Timestamp: 2}
+	inbox.Enqueue(context.Background(), event)
+	id, _ := event.Fingerprint()
+	if err := inbox.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer inbox.Stop()
+
+	waitForStatus(t, store, id, InboxStatusDead)
+	entry, _ := store.Get(id)
+	if entry.Attempts != 2 || entry.LastError != "downstream unavailable" {
+		t.Fatalf("dead entry = %+v", entry)
+	}
+
+	mu.Lock()
+	fail = false
+	mu.Unlock()
+	if err := inbox.Replay(id); err != nil {
+		t.Fatal(err)
+	}
+	waitForStatus(t, store, id, InboxStatusDone)
+}
@@ -0,0 +1,401 @@
+package webhooktest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// EndpointServer 内存实现的webhook端点管理API，用于在测试中替代WordGate服务端
+//
+// 配合httptest.NewServer使用:
+//
+//	fake := webhooktest.NewEndpointServer()
+//	server := httptest.NewServer(fake)
+//	defer server.Close()
+//
+//	client := wordgate.NewClient("app", "secret", server.URL)
+//	endpoint, _ := client.CreateWebhookEndpoint(&wordgate.CreateWebhookEndpointRequest{URL: handlerURL, Enabled: true})
+//	fake.Deliver(webhooktest.Fixture(wordgate.WebhookEventOrderPaid))
+type EndpointServer struct {
+	// AppCode 不为空时要求请求携带匹配的X-App-Code
+	AppCode string
+	// AppSecret 不为空时要求请求携带匹配的X-App-Secret
+	AppSecret string
+	// HTTPClient 投递事件时使用的HTTP客户端
+	HTTPClient *http.Client
+
+	mu             sync.Mutex
+	mux            *http.ServeMux
+	endpoints      map[uint64]*wordgate.WebhookEndpoint
+	secrets        map[uint64]string
+	deliveries     map[uint64][]wordgate.WebhookDelivery
+	nextEndpointID uint64
+	nextDeliveryID uint64
+}
+
+// NewEndpointServer 创建内存webhook端点管理API
+func NewEndpointServer() *EndpointServer {
+	s := &EndpointServer{
+		HTTPClient: &http.Client{
+			Timeout: time.Second * 30,
+		},
+		mux:        http.NewServeMux(),
+		endpoints:  make(map[uint64]*wordgate.WebhookEndpoint),
+		secrets:    make(map[uint64]string),
+		deliveries: make(map[uint64][]wordgate.WebhookDelivery),
+	}
+
+	s.mux.HandleFunc("POST /app/webhooks/endpoints", s.handleCreate)
+	s.mux.HandleFunc("GET /app/webhooks/endpoints", s.handleList)
+	s.mux.HandleFunc("GET /app/webhooks/endpoints/{id}", s.handleGet)
+	s.mux.HandleFunc("PUT /app/webhooks/endpoints/{id}", s.handleUpdate)
+	s.mux.HandleFunc("DELETE /app/webhooks/endpoints/{id}", s.handleDelete)
+	s.mux.HandleFunc("POST /app/webhooks/endpoints/{id}/rotate-secret", s.handleRotate)
+	s.mux.HandleFunc("GET /app/webhooks/endpoints/{id}/deliveries", s.handleDeliveries)
+	s.mux.HandleFunc("POST /app/webhooks/endpoints/{id}/deliveries/{deliveryID}/redeliver", s.handleRedeliver)
+	return s
+}
+
+// ServeHTTP 实现http.Handler接口
+func (s *EndpointServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if (s.AppCode != "" && r.Header.Get("X-App-Code") != s.AppCode) ||
+		(s.AppSecret != "" && r.Header.Get("X-App-Secret") != s.AppSecret) {
+		writeError(w, http.StatusUnauthorized, "invalid app credentials")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// Secret 返回端点当前的签名密钥
+func (s *EndpointServer) Secret(endpointID uint64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.secrets[endpointID]
+}
+
+// Deliver 将事件签名后投递到所有已启用且订阅了该事件类型的端点，并记录投递结果
+func (s *EndpointServer) Deliver(event *wordgate.WebhookEventData) []wordgate.WebhookDelivery {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	var targets []wordgate.WebhookEndpoint
+	for _, endpoint := range s.endpoints {
+		if endpoint.Enabled && subscribed(endpoint, event.EventType) {
+			targets = append(targets, *endpoint)
+		}
+	}
+	s.mu.Unlock()
+
+	var deliveries []wordgate.WebhookDelivery
+	for _, endpoint := range targets {
+		deliveries = append(deliveries, s.send(endpoint, event.EventType, string(payload), 1))
+	}
+	return deliveries
+}
+
+// send 向端点投递一次请求体并记录结果
+func (s *EndpointServer) send(endpoint wordgate.WebhookEndpoint, eventType wordgate.WebhookEventType, payload string, attempt int) wordgate.WebhookDelivery {
+	delivery := wordgate.WebhookDelivery{
+		EndpointID: endpoint.ID,
+		EventType:  eventType,
+		Attempt:    attempt,
+		Payload:    payload,
+		CreatedAt:  time.Now(),
+	}
+
+	start := time.Now()
+	statusCode, err := s.post(endpoint.URL, s.Secret(endpoint.ID), []byte(payload))
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	delivery.StatusCode = statusCode
+
+	switch {
+	case err != nil:
+		delivery.Status = wordgate.WebhookDeliveryStatusFailed
+		delivery.Error = err.Error()
+	case statusCode != http.StatusOK:
+		delivery.Status = wordgate.WebhookDeliveryStatusFailed
+		delivery.Error = fmt.Sprintf("unexpected HTTP status %d", statusCode)
+	default:
+		delivery.Status = wordgate.WebhookDeliveryStatusSucceeded
+	}
+
+	s.mu.Lock()
+	s.nextDeliveryID++
+	delivery.ID = s.nextDeliveryID
+	s.deliveries[endpoint.ID] = append(s.deliveries[endpoint.ID], delivery)
+	s.mu.Unlock()
+	return delivery
+}
+
+// post 签名并发送请求体，返回响应状态码
+func (s *EndpointServer) post(url, secret string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(wordgate.WebhookSignatureHeader, wordgate.GenerateSignatureHeader(time.Now().Unix(), body, secret))
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+func (s *EndpointServer) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var request wordgate.CreateWebhookEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.URL == "" {
+		writeError(w, http.StatusBadRequest, "url is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.nextEndpointID++
+	endpoint := &wordgate.WebhookEndpoint{
+		ID:          s.nextEndpointID,
+		AppID:       DefaultAppID,
+		URL:         request.URL,
+		Description: request.Description,
+		EventTypes:  request.EventTypes,
+		Enabled:     request.Enabled,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.endpoints[endpoint.ID] = endpoint
+	s.secrets[endpoint.ID] = newSecret()
+
+	created := *endpoint
+	created.Secret = s.secrets[endpoint.ID]
+	writeData(w, created)
+}
+
+func (s *EndpointServer) handleGet(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoint, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	writeData(w, endpoint)
+}
+
+func (s *EndpointServer) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	var request wordgate.UpdateWebhookEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.URL == "" {
+		writeError(w, http.StatusBadRequest, "url is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoint, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	endpoint.URL = request.URL
+	endpoint.Description = request.Description
+	endpoint.EventTypes = request.EventTypes
+	endpoint.Enabled = request.Enabled
+	endpoint.UpdatedAt = time.Now()
+	writeData(w, endpoint)
+}
+
+func (s *EndpointServer) handleDelete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoint, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	delete(s.endpoints, endpoint.ID)
+	delete(s.secrets, endpoint.ID)
+	delete(s.deliveries, endpoint.ID)
+	writeData(w, map[string]any{"id": endpoint.ID})
+}
+
+func (s *EndpointServer) handleList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var endpoints []wordgate.WebhookEndpoint
+	for id := uint64(1); id <= s.nextEndpointID; id++ {
+		if endpoint, ok := s.endpoints[id]; ok {
+			endpoints = append(endpoints, *endpoint)
+		}
+	}
+
+	page, pagination := paginate(r, len(endpoints))
+	writeData(w, wordgate.WebhookEndpointListResponse{
+		Data:       endpoints[page[0]:page[1]],
+		Pagination: pagination,
+	})
+}
+
+// handleRotate 立即替换签名密钥；Deliver只使用当前密钥签名，不模拟旧密钥的宽限期，
+// 因此忽略GracePeriodSeconds，响应中的PreviousSecretExpiresAt始终为空
+func (s *EndpointServer) handleRotate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoint, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	s.secrets[endpoint.ID] = newSecret()
+	endpoint.UpdatedAt = time.Now()
+
+	writeData(w, wordgate.RotateWebhookSecretResponse{Secret: s.secrets[endpoint.ID]})
+}
+
+func (s *EndpointServer) handleDeliveries(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	endpoint, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+
+	status := wordgate.WebhookDeliveryStatus(r.URL.Query().Get("status"))
+	eventType := wordgate.WebhookEventType(r.URL.Query().Get("event_type"))
+
+	// 最新的投递记录在前
+	var deliveries []wordgate.WebhookDelivery
+	all := s.deliveries[endpoint.ID]
+	for i := len(all) - 1; i >= 0; i-- {
+		if (status == "" || all[i].Status == status) && (eventType == "" || all[i].EventType == eventType) {
+			deliveries = append(deliveries, all[i])
+		}
+	}
+
+	page, pagination := paginate(r, len(deliveries))
+	writeData(w, wordgate.WebhookDeliveryListResponse{
+		Data:       deliveries[page[0]:page[1]],
+		Pagination: pagination,
+	})
+}
+
+func (s *EndpointServer) handleRedeliver(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	endpoint, ok := s.lookup(w, r)
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+
+	deliveryID, _ := strconv.ParseUint(r.PathValue("deliveryID"), 10, 64)
+	var previous *wordgate.WebhookDelivery
+	for i := range s.deliveries[endpoint.ID] {
+		if s.deliveries[endpoint.ID][i].ID == deliveryID {
+			previous = &s.deliveries[endpoint.ID][i]
+		}
+	}
+	if previous == nil {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "delivery not found")
+		return
+	}
+
+	// 同一请求体的最大尝试次数决定本次的尝试序号
+	attempt := 0
+	for _, delivery := range s.deliveries[endpoint.ID] {
+		if delivery.Payload == previous.Payload && delivery.Attempt > attempt {
+			attempt = delivery.Attempt
+		}
+	}
+	target, eventType, payload := *endpoint, previous.EventType, previous.Payload
+	s.mu.Unlock()
+
+	writeData(w, s.send(target, eventType, payload, attempt+1))
+}
+
+// lookup 根据路径中的id查找端点，不存在时写入404响应
+func (s *EndpointServer) lookup(w http.ResponseWriter, r *http.Request) (*wordgate.WebhookEndpoint, bool) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid endpoint id")
+		return nil, false
+	}
+	endpoint, ok := s.endpoints[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "webhook endpoint not found")
+		return nil, false
+	}
+	return endpoint, true
+}
+
+// subscribed 判断端点是否订阅了事件类型，未指定事件类型表示订阅全部
+func subscribed(endpoint *wordgate.WebhookEndpoint, eventType wordgate.WebhookEventType) bool {
+	if len(endpoint.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range endpoint.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// paginate 根据page/limit参数计算分页范围
+func paginate(r *http.Request, total int) ([2]int, wordgate.PaginationInfo) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+
+	start := min((page-1)*limit, total)
+	end := min(start+limit, total)
+	return [2]int{start, end}, wordgate.PaginationInfo{
+		CurrentPage: page,
+		PerPage:     limit,
+		Total:       int64(total),
+		TotalPages:  (total + limit - 1) / limit,
+	}
+}
+
+// writeData 以WordGate API响应格式写入数据
+func writeData(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(wordgate.APIResponse{Code: 0, Data: data})
+}
+
+// writeError 以WordGate API错误格式写入错误
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(wordgate.APIResponse{Code: status, Msg: message})
+}
+
+// newSecret 生成随机签名密钥
+func newSecret() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return "whsec_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,147 @@
+package webhooktest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// receiver 使用可替换密钥验签的webhook接收端，fail为true时返回500
+type receiver struct {
+	mu       sync.Mutex
+	secret   string
+	fail     bool
+	received []wordgate.WebhookEventType
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	secret, fail := r.secret, r.fail
+	r.mu.Unlock()
+	wordgate.NewWebhookHandler(secret, func(ctx context.Context, event *wordgate.WebhookEventData) error {
+		if fail {
+			return errors.New("receiver down")
+		}
+		r.mu.Lock()
+		r.received = append(r.received, event.EventType)
+		r.mu.Unlock()
+		return nil
+	}).ServeHTTP(w, req)
+}
+
+func (r *receiver) set(secret string, fail bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secret, r.fail = secret, fail
+}
+
+func TestEndpointServerClientRoundTrip(t *testing.T) {
+	fake := NewEndpointServer()
+	fake.AppCode, fake.AppSecret = "app", "app_secret"
+	api := httptest.NewServer(fake)
+	defer api.Close()
+	target := &receiver{}
+	receiverServer := httptest.NewServer(target)
+	defer receiverServer.Close()
+
+	client := wordgate.NewClient("app", "app_secret", api.URL)
+	if _, err := wordgate.NewClient("app", "wrong", api.URL).ListWebhookEndpoints(nil); err == nil {
+		t.Fatal("request with a wrong app secret succeeded")
+	}
+
+	// 注册
+	endpoint, err := client.CreateWebhookEndpoint(&wordgate.CreateWebhookEndpointRequest{
+		URL:        receiverServer.URL,
+		EventTypes: []wordgate.WebhookEventType{wordgate.WebhookEventOrderPaid},
+		Enabled:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if endpoint.Secret == "" || endpoint.Secret != fake.Secret(endpoint.ID) {
+		t.Fatalf("created endpoint secret = %q, want the fake's secret", endpoint.Secret)
+	}
+	target.set(endpoint.Secret, false)
+
+	// 查询与列表
+	got, err := client.GetWebhookEndpoint(endpoint.ID)
+	if err != nil || got.URL != receiverServer.URL || got.Secret != "" {
+		t.Fatalf("GetWebhookEndpoint() = %+v, %v", got, err)
+	}
+	client.CreateWebhookEndpoint(&wordgate.CreateWebhookEndpointRequest{URL: "http://127.0.0.1:1/unused"})
+	list, err := client.ListWebhookEndpoints(&wordgate.ListWebhookEndpointsRequest{Page: 2, Limit: 1})
+	if err != nil || len(list.Data) != 1 || list.Data[0].ID != endpoint.ID+1 || list.Pagination.Total != 2 {
+		t.Fatalf("ListWebhookEndpoints(page 2) = %+v, %v", list, err)
+	}
+
+	// 投递：只投递给已启用且订阅了该事件类型的端点
+	deliveries := fake.Deliver(Fixture(wordgate.WebhookEventOrderPaid))
+	if len(deliveries) != 1 || deliveries[0].Status != wordgate.WebhookDeliveryStatusSucceeded {
+		t.Fatalf("Deliver(order.paid) = %+v, want one success", deliveries)
+	}
+	if deliveries := fake.Deliver(Fixture(wordgate.WebhookEventOrderCancelled)); len(deliveries) != 0 {
+		t.Fatalf("Deliver(order.cancelled) = %+v, want none", deliveries)
+	}
+
+	// 更新订阅后，失败的投递可以重新投递
+	updated, err := client.UpdateWebhookEndpoint(endpoint.ID, &wordgate.UpdateWebhookEndpointRequest{
+		URL:     receiverServer.URL,
+		Enabled: true,
+	})
+	if err != nil || len(updated.EventTypes) != 0 {
+		t.Fatalf("UpdateWebhookEndpoint() = %+v, %v", updated, err)
+	}
+	target.set(endpoint.Secret, true)
+	failed := fake.Deliver(Fixture(wordgate.WebhookEventOrderCancelled))
+	if len(failed) != 1 || failed[0].Status != wordgate.WebhookDeliveryStatusFailed || failed[0].StatusCode != http.StatusInternalServerError {
+		t.Fatalf("Deliver() to a failing receiver = %+v", failed)
+	}
+	history, err := client.ListWebhookDeliveries(endpoint.ID, &wordgate.ListWebhookDeliveriesRequest{Status: wordgate.WebhookDeliveryStatusFailed})
+	if err != nil || len(history.Data) != 1 || history.Data[0].ID != failed[0].ID {
+		t.Fatalf("ListWebhookDeliveries(failed) = %+v, %v", history, err)
+	}
+	target.set(endpoint.Secret, false)
+	redelivered, err := client.RedeliverWebhookDelivery(endpoint.ID, failed[0].ID)
+	if err != nil || redelivered.Status != wordgate.WebhookDeliveryStatusSucceeded || redelivered.Attempt != 2 {
+		t.Fatalf("RedeliverWebhookDelivery() = %+v, %v", redelivered, err)
+	}
+
+	// 轮换密钥后使用新密钥签名，旧密钥立即失效
+	rotated, err := client.RotateWebhookEndpointSecret(endpoint.ID, &wordgate.RotateWebhookSecretRequest{GracePeriodSeconds: 3600})
+	if err != nil || rotated.Secret == endpoint.Secret || rotated.PreviousSecretExpiresAt != nil {
+		t.Fatalf("RotateWebhookEndpointSecret() = %+v, %v", rotated, err)
+	}
+	if deliveries := fake.Deliver(Fixture(wordgate.WebhookEventOrderPaid)); deliveries[0].StatusCode != http.StatusUnauthorized {
+		t.Fatalf("delivery checked with the old secret = %+v, want 401", deliveries[0])
+	}
+	target.set(rotated.Secret, false)
+	if deliveries := fake.Deliver(Fixture(wordgate.WebhookEventOrderPaid)); deliveries[0].Status != wordgate.WebhookDeliveryStatusSucceeded {
+		t.Fatalf("delivery checked with the new secret = %+v", deliveries[0])
+	}
+
+	// 删除
+	if err := client.DeleteWebhookEndpoint(endpoint.ID); err != nil {
+		t.Fatal(err)
+	}
+	var apiErr wordgate.APIError
+	if _, err := client.GetWebhookEndpoint(endpoint.ID); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("GetWebhookEndpoint() after delete error = %v, want a 404 APIError", err)
+	}
+
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	want := []wordgate.WebhookEventType{wordgate.WebhookEventOrderPaid, wordgate.WebhookEventOrderCancelled, wordgate.WebhookEventOrderPaid}
+	if len(target.received) != len(want) {
+		t.Fatalf("receiver processed %v, want %v", target.received, want)
+	}
+	for i := range want {
+		if target.received[i] != want[i] {
+			t.Fatalf("receiver processed %v, want %v", target.received, want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Package webhooktest provides helpers for testing WordGate webhook handlers locally.
+
+It builds realistic webhook events for every event type known to the SDK, signs them
+with wordgate.GenerateSignatureHeader and delivers them either to an http.Handler
+in-process or to a URL over HTTP. Delivery options can simulate replays, stale
+timestamps, bad signatures and redelivery storms.
+
+Usage example:
+
+	sender := webhooktest.NewSender("your_webhook_secret")
+
+	// Deliver a sample order.paid event to your handler
+	event := webhooktest.Fixture(wordgate.WebhookEventOrderPaid)
+	deliveries := sender.ServeHandler(handler, event)
+	if deliveries[0].StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d", deliveries[0].StatusCode)
+	}
+
+	// Customize the payload and check that stale requests are rejected
+	paid := webhooktest.OrderPaid("ORDER001")
+	paid.Amount = 1900
+	event = webhooktest.NewEvent(wordgate.WebhookEventOrderPaid, paid)
+	deliveries = sender.ServeHandler(handler, event, webhooktest.WithStaleTimestamp(10*time.Minute))
+*/
+package webhooktest
+
+import (
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// DefaultAppID 示例事件使用的应用ID
+const DefaultAppID uint64 = 1
+
+// NewEvent 使用当前时间戳将事件数据包装为webhook事件
+func NewEvent(eventType wordgate.WebhookEventType, data any) *wordgate.WebhookEventData {
+	return &wordgate.WebhookEventData{
+		EventType: eventType,
+		AppID:     DefaultAppID,
+		Data:      data,
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+// Fixture 返回指定事件类型的示例事件，未知事件类型返回nil
+func Fixture(eventType wordgate.WebhookEventType) *wordgate.WebhookEventData {
+	var data any
+	switch eventType {
+	case wordgate.WebhookEventOrderPaid:
+		data = OrderPaid("WG202401010001")
+	case wordgate.WebhookEventOrderCancelled:
+		data = OrderCancelled("WG202401010001")
+	case wordgate.WebhookEventOrderRefunded:
+		data = OrderRefunded("WG202401010001")
+	case wordgate.WebhookEventMembershipActivated:
+		data = MembershipActivated(1001, "PREMIUM")
+	case wordgate.WebhookEventMembershipExpired:
+		data = MembershipExpired(1001, "PREMIUM")
+	case wordgate.WebhookEventMembershipCancelled:
+		data = MembershipCancelled(1001, "PREMIUM")
+	case wordgate.WebhookEventMembershipRenewed:
+		data = MembershipRenewed(1001, "PREMIUM")
+	case wordgate.WebhookEventUserCreated:
+		data = UserCreated(1001, "user123")
+	case wordgate.WebhookEventUserDisabled:
+		data = UserDisabled(1001, "user123")
+	case wordgate.WebhookEventPaymentFailed:
+		data = PaymentFailed("WG202401010001")
+	default:
+		return nil
+	}
+	return NewEvent(eventType, data)
+}
+
+// Fixtures 返回SDK已知全部事件类型的示例事件
+func Fixtures() []*wordgate.WebhookEventData {
+	var events []*wordgate.WebhookEventData
+	for _, eventType := range wordgate.WebhookEventTypes() {
+		events = append(events, Fixture(eventType))
+	}
+	return events
+}
+
+// OrderPaid 返回订单支付成功事件的示例数据
+func OrderPaid(orderNo string) *wordgate.WebhookOrderPaidData {
+	paidAt := now()
+	return &wordgate.WebhookOrderPaidData{
+		WordgateOrderNo: orderNo,
+		Amount:          9900,
+		Currency:        "CNY",
+		IsPaid:          true,
+		PaidAt:          &paidAt,
+		AppID:           DefaultAppID,
+	}
+}
+
+// OrderCancelled 返回订单取消事件的示例数据
+func OrderCancelled(orderNo string) *wordgate.WebhookOrderCancelledData {
+	cancelledAt := now()
+	return &wordgate.WebhookOrderCancelledData{
+		WordgateOrderNo: orderNo,
+		Amount:          9900,
+		Currency:        "CNY",
+		CancelledAt:     &cancelledAt,
+		AppID:           DefaultAppID,
+		Reason:          "payment timeout",
+	}
+}
+
+// OrderRefunded 返回订单全额退款事件的示例数据
+func OrderRefunded(orderNo string) *wordgate.WebhookOrderRefundedData {
+	refundedAt := now()
+	return &wordgate.WebhookOrderRefundedData{
+		WordgateOrderNo: orderNo,
+		RefundNo:        "RF202401010001",
+		Amount:          9900,
+		RefundAmount:    9900,
+		Currency:        "CNY",
+		IsFullRefund:    true,
+		RefundedAt:      &refundedAt,
+		AppID:           DefaultAppID,
+		Reason:          "customer request",
+	}
+}
+
+// MembershipActivated 返回会员变动事件的示例数据
+func MembershipActivated(userID uint64, tierCode string) *wordgate.WebhookMembershipActivatedData {
+	return &wordgate.WebhookMembershipActivatedData{
+		UserID:    userID,
+		TierCode:  tierCode,
+		ExpiresAt: now().AddDate(0, 1, 0).Format(time.RFC3339),
+		AppID:     DefaultAppID,
+	}
+}
+
+// MembershipExpired 返回会员到期事件的示例数据
+func MembershipExpired(userID uint64, tierCode string) *wordgate.WebhookMembershipExpiredData {
+	return &wordgate.WebhookMembershipExpiredData{
+		UserID:    userID,
+		TierCode:  tierCode,
+		ExpiredAt: now().Format(time.RFC3339),
+		AppID:     DefaultAppID,
+	}
+}
+
+// MembershipCancelled 返回会员取消事件的示例数据
+func MembershipCancelled(userID uint64, tierCode string) *wordgate.WebhookMembershipCancelledData {
+	return &wordgate.WebhookMembershipCancelledData{
+		UserID:      userID,
+		TierCode:    tierCode,
+		CancelledAt: now().Format(time.RFC3339),
+		AppID:       DefaultAppID,
+		Reason:      "user request",
+	}
+}
+
+// MembershipRenewed 返回会员续费事件的示例数据
+func MembershipRenewed(userID uint64, tierCode string) *wordgate.WebhookMembershipRenewedData {
+	previous := now()
+	return &wordgate.WebhookMembershipRenewedData{
+		UserID:            userID,
+		TierCode:          tierCode,
+		PreviousExpiresAt: previous.Format(time.RFC3339),
+		ExpiresAt:         previous.AddDate(0, 1, 0).Format(time.RFC3339),
+		OrderNo:           "WG202401010002",
+		AppID:             DefaultAppID,
+	}
+}
+
+// UserCreated 返回用户创建事件的示例数据
+func UserCreated(userID uint64, uid string) *wordgate.WebhookUserCreatedData {
+	createdAt := now()
+	return &wordgate.WebhookUserCreatedData{
+		UserID:    userID,
+		UID:       uid,
+		Nickname:  "Test User",
+		Email:     uid + "@example.com",
+		CreatedAt: &createdAt,
+		AppID:     DefaultAppID,
+	}
+}
+
+// UserDisabled 返回用户禁用事件的示例数据
+func UserDisabled(userID uint64, uid string) *wordgate.WebhookUserDisabledData {
+	disabledAt := now()
+	return &wordgate.WebhookUserDisabledData{
+		UserID:     userID,
+		UID:        uid,
+		DisabledAt: &disabledAt,
+		AppID:      DefaultAppID,
+		Reason:     "terms violation",
+	}
+}
+
+// PaymentFailed 返回支付失败事件的示例数据
+func PaymentFailed(orderNo string) *wordgate.WebhookPaymentFailedData {
+	failedAt := now()
+	return &wordgate.WebhookPaymentFailedData{
+		WordgateOrderNo: orderNo,
+		Amount:          9900,
+		Currency:        "CNY",
+		Provider:        "stripe",
+		IntentID:        "pi_test_0001",
+		FailureCode:     "card_declined",
+		FailureMessage:  "Your card was declined.",
+		FailedAt:        &failedAt,
+		AppID:           DefaultAppID,
+	}
+}
+
+// now 返回截断到秒的UTC当前时间，保证JSON往返后数据一致
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
@@ -0,0 +1,220 @@
+package webhooktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+// Sender 签名并投递webhook事件
+type Sender struct {
+	// Secret 签名密钥
+	Secret string
+	// HTTPClient 投递到URL时使用的HTTP客户端
+	HTTPClient *http.Client
+}
+
+// Delivery 单次投递的结果
+type Delivery struct {
+	// Signature 本次请求的X-Webhook-Signature header值
+	Signature string
+	// StatusCode 响应状态码
+	StatusCode int
+	// Body 响应体
+	Body string
+	// Err 投递失败时的错误
+	Err error
+}
+
+// DeliveryOption 投递选项
+type DeliveryOption func(*deliveryConfig)
+
+type deliveryConfig struct {
+	timestamp    time.Time
+	staleEvent   bool
+	badSignature bool
+	replays      int
+	redeliveries int
+}
+
+// WithTimestamp 使用指定时间作为签名时间戳
+func WithTimestamp(t time.Time) DeliveryOption {
+	return func(c *deliveryConfig) {
+		c.timestamp = t
+	}
+}
+
+// WithStaleTimestamp 使用过期的签名时间戳，并将事件体中的Timestamp改为同一时间，模拟延迟或重放的旧请求
+func WithStaleTimestamp(age time.Duration) DeliveryOption {
+	return func(c *deliveryConfig) {
+		c.timestamp = time.Now().Add(-age)
+		c.staleEvent = true
+	}
+}
+
+// WithBadSignature 使用错误的密钥签名，模拟伪造请求
+func WithBadSignature() DeliveryOption {
+	return func(c *deliveryConfig) {
+		c.badSignature = true
+	}
+}
+
+// WithReplays 在首次投递后原样重发n次(相同请求体和签名)，模拟重放攻击
+func WithReplays(n int) DeliveryOption {
+	return func(c *deliveryConfig) {
+		c.replays = n
+	}
+}
+
+// WithRedeliveryStorm 在首次投递后并发重新投递n次(相同请求体、重新签名)，模拟重试风暴
+func WithRedeliveryStorm(n int) DeliveryOption {
+	return func(c *deliveryConfig) {
+		c.redeliveries = n
+	}
+}
+
+// NewSender 创建webhook测试发送器
+func NewSender(secret string) *Sender {
+	return &Sender{
+		Secret: secret,
+		HTTPClient: &http.Client{
+			Timeout: time.Second * 30,
+		},
+	}
+}
+
+// Sign 序列化事件并生成X-Webhook-Signature header值
+func (s *Sender) Sign(event *wordgate.WebhookEventData, opts ...DeliveryOption) (body []byte, signature string, err error) {
+	config := newDeliveryConfig(opts)
+	body, err = config.marshal(event)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, s.signature(config, body), nil
+}
+
+// ServeHandler 将事件投递给http.Handler，返回每次投递的结果
+func (s *Sender) ServeHandler(handler http.Handler, event *wordgate.WebhookEventData, opts ...DeliveryOption) []Delivery {
+	return s.deliver(event, opts, func(body []byte, signature string) Delivery {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(wordgate.WebhookSignatureHeader, signature)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		return Delivery{
+			Signature:  signature,
+			StatusCode: recorder.Code,
+			Body:       recorder.Body.String(),
+		}
+	})
+}
+
+// Post 将事件通过HTTP POST投递到指定URL，返回每次投递的结果
+func (s *Sender) Post(url string, event *wordgate.WebhookEventData, opts ...DeliveryOption) []Delivery {
+	return s.deliver(event, opts, func(body []byte, signature string) Delivery {
+		delivery := Delivery{Signature: signature}
+
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			delivery.Err = fmt.Errorf("failed to create HTTP request: %w", err)
+			return delivery
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(wordgate.WebhookSignatureHeader, signature)
+
+		resp, err := s.HTTPClient.Do(req)
+		if err != nil {
+			delivery.Err = fmt.Errorf("failed to send HTTP request: %w", err)
+			return delivery
+		}
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			delivery.Err = fmt.Errorf("failed to read response body: %w", err)
+		}
+		delivery.StatusCode = resp.StatusCode
+		delivery.Body = string(respBody)
+		return delivery
+	})
+}
+
+// deliver 按投递选项执行首次投递、重放和重试风暴
+func (s *Sender) deliver(event *wordgate.WebhookEventData, opts []DeliveryOption, send func(body []byte, signature string) Delivery) []Delivery {
+	config := newDeliveryConfig(opts)
+
+	body, err := config.marshal(event)
+	if err != nil {
+		return []Delivery{{Err: err}}
+	}
+
+	signature := s.signature(config, body)
+	deliveries := []Delivery{send(body, signature)}
+
+	// 重放: 原样重发同一请求
+	for i := 0; i < config.replays; i++ {
+		deliveries = append(deliveries, send(body, signature))
+	}
+
+	// 重试风暴: 并发投递重新签名的同一事件
+	if config.redeliveries > 0 {
+		storm := make([]Delivery, config.redeliveries)
+		var wg sync.WaitGroup
+		for i := range storm {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				storm[i] = send(body, s.signature(config, body))
+			}(i)
+		}
+		wg.Wait()
+		deliveries = append(deliveries, storm...)
+	}
+
+	return deliveries
+}
+
+// signature 根据投递选项生成签名header值
+func (s *Sender) signature(config *deliveryConfig, body []byte) string {
+	timestamp := config.timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	secret := s.Secret
+	if config.badSignature {
+		secret = s.Secret + "-invalid"
+	}
+	return wordgate.GenerateSignatureHeader(timestamp.Unix(), body, secret)
+}
+
+// marshal 序列化事件，过期投递时使用签名时间戳作为事件时间
+func (c *deliveryConfig) marshal(event *wordgate.WebhookEventData) ([]byte, error) {
+	if c.staleEvent {
+		stale := *event
+		stale.Timestamp = c.timestamp.Unix()
+		event = &stale
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+	return body, nil
+}
+
+func newDeliveryConfig(opts []DeliveryOption) *deliveryConfig {
+	config := &deliveryConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
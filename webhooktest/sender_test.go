@@ -0,0 +1,73 @@
+package webhooktest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	wordgate "github.com/wordgate/wordgate-sdk"
+)
+
+const testSecret = "whsec_test"
+
+func TestSenderDeliveryPassesVerification(t *testing.T) {
+	sender := NewSender(testSecret)
+	for _, event := range Fixtures() {
+		body, signature, err := sender.Sign(event)
+		if err != nil {
+			t.Fatalf("Sign(%s) error = %v", event.EventType, err)
+		}
+		if err := wordgate.VerifySignature(signature, body, testSecret, 300); err != nil {
+			t.Fatalf("VerifySignature(%s) error = %v", event.EventType, err)
+		}
+	}
+
+	var received *wordgate.WebhookEventData
+	handler := wordgate.NewWebhookHandler(testSecret, func(ctx context.Context, event *wordgate.WebhookEventData) error {
+		received = event
+		return nil
+	})
+	deliveries := sender.ServeHandler(handler, Fixture(wordgate.WebhookEventOrderPaid))
+	if len(deliveries) != 1 || deliveries[0].StatusCode != http.StatusOK {
+		t.Fatalf("deliveries = %+v, want one 200", deliveries)
+	}
+	if received == nil || received.EventType != wordgate.WebhookEventOrderPaid {
+		t.Fatalf("handler received %+v", received)
+	}
+}
+
+func TestSenderInvalidDeliveriesAreRejected(t *testing.T) {
+	sender := NewSender(testSecret)
+	event := Fixture(wordgate.WebhookEventOrderPaid)
+
+	body, signature, err := sender.Sign(event, WithStaleTimestamp(10*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wordgate.VerifySignature(signature, body, testSecret, 300); !errors.Is(err, wordgate.ErrWebhookTimestampTooOld) {
+		t.Fatalf("stale: VerifySignature() error = %v, want ErrWebhookTimestampTooOld", err)
+	}
+
+	body, signature, err = sender.Sign(event, WithBadSignature())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wordgate.VerifySignature(signature, body, testSecret, 300); !errors.Is(err, wordgate.ErrWebhookSignatureMismatch) {
+		t.Fatalf("bad signature: VerifySignature() error = %v, want ErrWebhookSignatureMismatch", err)
+	}
+
+	// 处理函数不应被调用
+	handler := wordgate.NewWebhookHandler(testSecret, func(ctx context.Context, event *wordgate.WebhookEventData) error {
+		t.Error("invalid delivery reached the process function")
+		return nil
+	})
+	for _, opt := range []DeliveryOption{WithStaleTimestamp(10 * time.Minute), WithBadSignature()} {
+		for _, delivery := range sender.ServeHandler(handler, event, opt) {
+			if delivery.StatusCode == http.StatusOK {
+				t.Fatalf("invalid delivery accepted: %+v", delivery)
+			}
+		}
+	}
+}